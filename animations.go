@@ -0,0 +1,21 @@
+package hyprls
+
+import (
+	"github.com/ewen-lbh/hyprls/config"
+	"go.lsp.dev/protocol"
+)
+
+// definedBeziers returns the names of the curves defined in uri and its related files (see relatedFiles)
+func definedBeziers(uri protocol.URI) map[string]bool {
+	defined := map[string]bool{config.DefaultBezier: true}
+	for _, related := range relatedFiles(uri) {
+		contents, err := file(related)
+		if err != nil {
+			continue
+		}
+		for _, definition := range config.Beziers(contents) {
+			defined[definition.Name] = true
+		}
+	}
+	return defined
+}
@@ -0,0 +1,100 @@
+package hyprls
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ewen-lbh/hyprls/config"
+	parser_data "github.com/ewen-lbh/hyprls/parser/data"
+	"go.lsp.dev/protocol"
+)
+
+type submapBlock struct {
+	Name string
+	// NameRange is the range of the submap's name in its submap = NAME line
+	NameRange protocol.Range
+	// Range spans from the submap = NAME line to the submap = reset line, or to the end of the file if the submap is never reset
+	Range protocol.Range
+}
+
+// submapBlocks returns the submaps of a file. Binds defined in a submap's range only apply in that submap.
+func submapBlocks(contents string) []submapBlock {
+	lines := strings.Split(contents, "\n")
+	blocks := make([]submapBlock, 0)
+	var current *submapBlock
+	closeCurrent := func(end protocol.Position) {
+		if current != nil {
+			current.Range.End = end
+			blocks = append(blocks, *current)
+			current = nil
+		}
+	}
+
+	for i, line := range lines {
+		arguments := lineArguments(line, i)
+		if config.LineKey(line) != "submap" || len(arguments) == 0 {
+			continue
+		}
+
+		closeCurrent(protocol.Position{Line: uint32(i), Character: uint32(utf8.RuneCountInString(line))})
+		if arguments[0].Value == "reset" || arguments[0].Value == "" {
+			continue
+		}
+		current = &submapBlock{
+			Name:      arguments[0].Value,
+			NameRange: arguments[0].Range,
+			Range: protocol.Range{
+				Start: protocol.Position{Line: uint32(i), Character: 0},
+			},
+		}
+	}
+	closeCurrent(protocol.Position{Line: uint32(len(lines) - 1), Character: uint32(utf8.RuneCountInString(lines[len(lines)-1]))})
+	return blocks
+}
+
+// submapReferenceAt returns the name of the submap the cursor is on, in a bind = MODS, KEY, submap, NAME line
+func submapReferenceAt(line string, position protocol.Position) (string, bool) {
+	arguments := lineArguments(line, int(position.Line))
+	if keyword, found := parser_data.FindKeyword(config.LineKey(line)); !found || keyword.Name != "bind" || len(arguments) < 4 || arguments[2].Value != "submap" {
+		return "", false
+	}
+	if !within(arguments[3].Range, position) || arguments[3].Value == "reset" {
+		return "", false
+	}
+	return arguments[3].Value, true
+}
+
+// submapCompletions proposes the submaps defined in the file, and reset, in the NAME argument of a bind = MODS, KEY, submap, NAME line.
+// ok is false if the cursor is somewhere else.
+func submapCompletions(uri protocol.URI, line string, position protocol.Position) (items []protocol.CompletionItem, ok bool) {
+	contents, err := file(uri)
+	if err != nil {
+		return nil, false
+	}
+
+	beforeCursor := textBeforeCursor(line, position)
+	arguments := lineArguments(beforeCursor, int(position.Line))
+	if len(arguments) != 4 || arguments[2].Value != "submap" {
+		return nil, false
+	}
+
+	typed := typedWord(beforeCursor, func(r rune) bool { return !unicode.IsSpace(r) && r != ',' })
+	names := []string{"reset"}
+	for _, block := range submapBlocks(contents) {
+		if !slices.Contains(names, block.Name) {
+			names = append(names, block.Name)
+		}
+	}
+
+	items = make([]protocol.CompletionItem, 0, len(names))
+	for _, name := range names {
+		item := wordCompletion(name, protocol.CompletionItemKindModule, typed, position)
+		if name == "reset" {
+			item.Detail = "Go back to the global submap"
+		}
+		items = append(items, item)
+	}
+	return items, true
+}
@@ -0,0 +1,32 @@
+package hyprls
+
+import (
+	"os"
+	"slices"
+
+	"github.com/ewen-lbh/hyprls/config"
+)
+
+// Check returns the problems of the configuration file at path, or of the standard input if path is -, sorted by position.
+// These are the problems the server publishes as diagnostics, see diagnose: the files it sources are read, so that the variables and curves they define are known.
+func Check(path string) ([]config.Diagnostic, error) {
+	var cfg *config.Config
+	var err error
+	if path == "-" {
+		cfg, err = config.ParseConfig(os.Stdin)
+	} else {
+		cfg, err = config.ReadConfig(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	diagnostics := cfg.Validate(nil)
+	slices.SortStableFunc(diagnostics, func(a, b config.Diagnostic) int {
+		if a.Range.Start.Line != b.Range.Start.Line {
+			return a.Range.Start.Line - b.Range.Start.Line
+		}
+		return a.Range.Start.Column - b.Range.Start.Column
+	})
+	return diagnostics, nil
+}
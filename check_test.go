@@ -0,0 +1,70 @@
+package hyprls
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+
+	"go.lsp.dev/uri"
+	"go.uber.org/zap"
+)
+
+func TestCheckReportsTheSameProblemsAsDiagnose(t *testing.T) {
+	resetState(zap.NewNop())
+	directory := t.TempDir()
+	main := filepath.Join(directory, "hyprland.conf")
+	options.HyprlandConfigPath = main
+
+	contents := `source = ./variables.conf
+source = ./missing.conf
+general {
+    gaps_in = $gap
+    border_size = $undefined
+    border_size = 2
+    layout = spiral
+}
+decoration {
+bezier = broken, 0, 1
+animation = windows, 1, 5, snappy
+animation = windowz, 2, -1, nowhere
+bind = SUPER, Q, exec, kitty
+bind = SUPER, Q, killactive
+windowrulev2 = floating, class:kitty
+windowrulev2 = float, clas:kitty
+layerrule = blurr, waybar
+monitor = DP-1, 1920x1080, 0x0
+exec-once = hyprpaper -c /nonexistent/hyprpaper.conf
+`
+	if err := os.WriteFile(main, []byte(contents), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(directory, "variables.conf"), []byte("$gap = 5\nbezier = snappy, 0.05, 0.9, 0.1, 1.05\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	checked, err := Check(main)
+	if err != nil {
+		t.Fatalf("while checking: %s", err)
+	}
+	fromCheck := make([]string, 0, len(checked))
+	for _, diagnostic := range checked {
+		fromCheck = append(fromCheck, fmt.Sprintf("%d:%d %s %s", diagnostic.Range.Start.Line, diagnostic.Range.Start.Column, diagnostic.Code, diagnostic.Message))
+	}
+
+	fromDiagnose := make([]string, 0)
+	for _, diagnostic := range diagnose(uri.File(main), contents) {
+		fromDiagnose = append(fromDiagnose, fmt.Sprintf("%d:%d %s %s", diagnostic.Range.Start.Line, diagnostic.Range.Start.Character, diagnostic.Code, diagnostic.Message))
+	}
+	slices.Sort(fromCheck)
+	slices.Sort(fromDiagnose)
+
+	if len(fromCheck) < 10 {
+		t.Fatalf("expected problems of most kinds to be found, got %v", fromCheck)
+	}
+	if !reflect.DeepEqual(fromCheck, fromDiagnose) {
+		t.Errorf("expected check and diagnose to report the same problems, got\n%v\nand\n%v", fromCheck, fromDiagnose)
+	}
+}
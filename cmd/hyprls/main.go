@@ -1,31 +1,200 @@
 package main
 
 import (
+	"encoding/json"
+	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
 
 	hyprls "github.com/ewen-lbh/hyprls"
-	"go.uber.org/zap"
+	"github.com/ewen-lbh/hyprls/config"
+	"github.com/ewen-lbh/hyprls/parser"
+	parser_data "github.com/ewen-lbh/hyprls/parser/data"
+	"github.com/pmezard/go-difflib/difflib"
 )
 
 var OutputServerLogs string
 
 func main() {
-	logconf := zap.NewDevelopmentConfig()
+	flag.BoolVar(&hyprls.NoWorkspaceScan, "no-workspace-scan", false, "only handle opened files, without looking into the files they source")
+	lint := flag.String("lint", "", "check the given file instead of starting the server, same as hyprls check FILE")
+	listen := flag.String("listen", "", "serve LSP over TCP on this address, e.g. :7000, instead of the standard input and output")
+	format := flag.Bool("format", false, "format the files given as arguments instead of starting the server, printing the changes as a diff")
+	write := flag.Bool("w", false, "with --format, write the formatted files instead of printing the diff")
+	schema := flag.Bool("schema", false, "print a JSON Schema (draft-07) of configurations instead of starting the server")
+	version := flag.Bool("version", false, "print the version of hyprls and of the documentation it embeds, same as hyprls version")
+	flag.Parse()
+
+	if *version || flag.Arg(0) == "version" {
+		printVersion()
+		return
+	}
+
+	if *schema {
+		os.Exit(printSchema())
+	}
+
+	if *format {
+		os.Exit(formatFiles(flag.Args(), *write))
+	}
+
+	if *lint != "" {
+		os.Exit(check([]string{*lint}))
+	}
+
+	if flag.Arg(0) == "check" {
+		os.Exit(check(flag.Args()[1:]))
+	}
+
+	outputPaths := []string{"stderr"}
 	if OutputServerLogs != "" {
-		logconf.OutputPaths = []string{OutputServerLogs, "stderr"}
+		outputPaths = append([]string{OutputServerLogs}, outputPaths...)
 	}
-	logger, err := logconf.Build()
+	logger, err := hyprls.NewLogger(outputPaths...)
 	if err != nil {
 		fmt.Printf("while building logger: %s", err)
 		os.Exit(1)
 	}
 
-	logger.Debug("going to start server")
+	logClientIn := ""
 	if OutputServerLogs != "" {
-		hyprls.StartServer(logger, filepath.Dir(OutputServerLogs))
-	} else {
-		hyprls.StartServer(logger, "")
+		logClientIn = filepath.Dir(OutputServerLogs)
+	}
+
+	logger.Debug("going to start server")
+	if *listen != "" {
+		if err := hyprls.ListenAndServe(logger, *listen, logClientIn); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	hyprls.StartServer(logger, logClientIn)
+}
+
+// check runs hyprls check [--format text|json] FILE, and returns the exit code:
+// 1 if the file has errors, 2 if it could not be checked.
+func check(args []string) int {
+	flags := flag.NewFlagSet("check", flag.ExitOnError)
+	format := flags.String("format", "text", "how to print diagnostics: text or json")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: hyprls check [--format text|json] FILE")
+		fmt.Fprintln(flags.Output(), "Reports problems in FILE, or in the standard input if FILE is -.")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if flags.NArg() != 1 || (*format != "text" && *format != "json") {
+		flags.Usage()
+		return 2
+	}
+
+	path := flags.Arg(0)
+	diagnostics, err := hyprls.Check(path)
+	if path == "-" {
+		path = "stdin"
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "while checking %s: %s\n", path, err)
+		return 2
+	}
+
+	switch *format {
+	case "json":
+		encoded, err := json.MarshalIndent(diagnostics, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "while encoding diagnostics: %s\n", err)
+			return 2
+		}
+		fmt.Println(string(encoded))
+	default:
+		for _, diagnostic := range diagnostics {
+			fmt.Printf("%s:%d:%d: %s: %s\n", path, diagnostic.Range.Start.Line+1, diagnostic.Range.Start.Column+1, diagnostic.Severity, diagnostic.Message)
+		}
+	}
+
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Severity == config.SeverityError {
+			return 1
+		}
+	}
+	return 0
+}
+
+// printVersion runs hyprls version: the documentation can be the culprit when completions or diagnostics are missing for recent variables
+func printVersion() {
+	unknown := func(value string) string {
+		if value == "" {
+			return "unknown"
+		}
+		return value
+	}
+	fmt.Printf("hyprls %s\n", hyprls.BuildVersion())
+	fmt.Printf("hyprland-wiki commit: %s\n", unknown(parser_data.WikiCommit))
+	fmt.Printf("documented Hyprland version: %s\n", unknown(parser_data.WikiHyprlandVersion))
+}
+
+// printSchema runs hyprls --schema, and returns the exit code
+func printSchema() int {
+	encoded, err := json.MarshalIndent(parser_data.JSONSchema(), "", "  ")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "while encoding schema: %s\n", err)
+		return 1
+	}
+	fmt.Println(string(encoded))
+	return 0
+}
+
+// formatFiles runs hyprls --format [-w] FILE..., and returns the exit code: 2 if a file could not be formatted.
+// Without -w, the changes are printed as a unified diff, like gofmt -d.
+func formatFiles(paths []string, write bool) int {
+	if len(paths) == 0 {
+		fmt.Fprintln(os.Stderr, "Usage: hyprls --format [-w] FILE...")
+		return 2
+	}
+
+	exitCode := 0
+	for _, path := range paths {
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "while reading %s: %s\n", path, err)
+			exitCode = 2
+			continue
+		}
+
+		formatted, err := parser.Format(string(contents))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "while formatting %s: %s\n", path, err)
+			exitCode = 2
+			continue
+		}
+
+		if formatted == string(contents) {
+			continue
+		}
+
+		if write {
+			if err := os.WriteFile(path, []byte(formatted), 0644); err != nil {
+				fmt.Fprintf(os.Stderr, "while writing %s: %s\n", path, err)
+				exitCode = 2
+			}
+			continue
+		}
+
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(string(contents)),
+			B:        difflib.SplitLines(formatted),
+			FromFile: path + ".orig",
+			ToFile:   path,
+			Context:  3,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "while computing the changes to %s: %s\n", path, err)
+			exitCode = 2
+			continue
+		}
+		fmt.Print(diff)
 	}
+	return exitCode
 }
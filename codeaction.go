@@ -0,0 +1,282 @@
+package hyprls
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ewen-lbh/hyprls/parser"
+	parser_data "github.com/ewen-lbh/hyprls/parser/data"
+	"go.lsp.dev/protocol"
+)
+
+func (h Handler) CodeAction(ctx context.Context, params *protocol.CodeActionParams) ([]protocol.CodeAction, error) {
+	contents, err := file(params.TextDocument.URI)
+	if err != nil {
+		return nil, fmt.Errorf("while reading file: %w", err)
+	}
+
+	document, err := parser.Parse(contents)
+	if err != nil {
+		return nil, fmt.Errorf("while parsing: %w", err)
+	}
+
+	actions := make([]protocol.CodeAction, 0)
+	for _, deprecated := range deprecatedAssignments(document) {
+		line := uint32(deprecated.Assignment.Position.Line)
+		if line < params.Range.Start.Line || line > params.Range.End.Line || deprecated.Definition.ReplacedWith == "" {
+			continue
+		}
+
+		actions = append(actions, protocol.CodeAction{
+			Title:       fmt.Sprintf("Replace with %s", deprecated.Definition.ReplacedWith),
+			Kind:        protocol.QuickFix,
+			IsPreferred: true,
+			Edit: &protocol.WorkspaceEdit{
+				Changes: map[protocol.DocumentURI][]protocol.TextEdit{
+					params.TextDocument.URI: replaceDeprecatedAssignment(contents, deprecated),
+				},
+			},
+		})
+	}
+
+	for _, misplaced := range misplacedAssignments(document) {
+		line := uint32(misplaced.Assignment.Position.Line)
+		if line < params.Range.Start.Line || line > params.Range.End.Line {
+			continue
+		}
+
+		actions = append(actions, moveIntoSectionAction(params.TextDocument.URI, contents, document, misplaced))
+	}
+
+	actions = append(actions, convertColorLiteralActions(params.TextDocument.URI, contents, document, params.Range.Start)...)
+
+	if action, ok := insertMissingDefaultsAction(params.TextDocument.URI, contents, document, params.Range.Start); ok {
+		actions = append(actions, action)
+	}
+
+	for line := params.Range.Start.Line; line <= params.Range.End.Line; line++ {
+		if action, ok := setToDefaultAction(params.TextDocument.URI, contents, document, line); ok {
+			actions = append(actions, action)
+		}
+		if action, ok := addDimConfigurationAction(params.TextDocument.URI, contents, document, line); ok {
+			actions = append(actions, action)
+		}
+	}
+	return actions, nil
+}
+
+// setToDefaultAction returns the code action that completes a line consisting of a variable name only, e.g. "gaps_in" in a general section,
+// by appending " = " and the variable's default value. There is no action if the variable has no default.
+func setToDefaultAction(uri protocol.DocumentURI, contents string, document parser.Section, line uint32) (protocol.CodeAction, bool) {
+	lines := strings.Split(contents, "\n")
+	if int(line) >= len(lines) {
+		return protocol.CodeAction{}, false
+	}
+
+	withoutComment, _, _ := strings.Cut(lines[line], "#")
+	name := strings.TrimSpace(withoutComment)
+	if name == "" || strings.ContainsAny(name, "= \t{}") {
+		return protocol.CodeAction{}, false
+	}
+
+	// The root of the document is not a section block
+	var section *parser.Section
+	for _, subsection := range document.Subsections {
+		if section = currentSection(subsection, protocol.Position{Line: line}); section != nil {
+			break
+		}
+	}
+	if section == nil {
+		return protocol.CodeAction{}, false
+	}
+
+	def := parser_data.FindVariableDefinitionInSection(section.Name, name)
+	if def == nil || def.Default == "" || def.Default == "[[Empty]]" {
+		return protocol.CodeAction{}, false
+	}
+
+	end := protocol.Position{Line: line, Character: uint32(utf8.RuneCountInString(strings.TrimRight(withoutComment, " \t")))}
+	return protocol.CodeAction{
+		Title: fmt.Sprintf("Set to default: %s", def.Default),
+		Kind:  protocol.QuickFix,
+		Edit: &protocol.WorkspaceEdit{
+			Changes: map[protocol.DocumentURI][]protocol.TextEdit{
+				uri: {{Range: collapsedRange(end), NewText: " = " + def.Default}},
+			},
+		},
+	}, true
+}
+
+// dimVariables are the variables configuring how inactive windows are dimmed once decoration:dim_inactive is enabled
+var dimVariables = []string{"dim_strength", "dim_special"}
+
+// addDimConfigurationAction returns the code action that sets the dimVariables not set yet to their default values,
+// right below a line enabling dim_inactive, either in a decoration section or as decoration:dim_inactive at the root of the document.
+func addDimConfigurationAction(uri protocol.DocumentURI, contents string, document parser.Section, line uint32) (protocol.CodeAction, bool) {
+	section := currentSection(document, protocol.Position{Line: line})
+	if section == nil {
+		return protocol.CodeAction{}, false
+	}
+
+	var prefix string
+	switch {
+	case strings.EqualFold(section.Name, "decoration"):
+	case section.Start == document.Start && section.End == document.End:
+		prefix = "decoration:"
+	default:
+		return protocol.CodeAction{}, false
+	}
+
+	var dimInactive *parser.Assignment
+	set := make(map[string]bool)
+	for i, assignment := range section.Assignments {
+		set[assignment.Key] = true
+		if assignment.Key == prefix+"dim_inactive" && assignment.Position.Line == int(line) {
+			dimInactive = &section.Assignments[i]
+		}
+	}
+	if dimInactive == nil {
+		return protocol.CodeAction{}, false
+	}
+	if enabled, err := parser.ParseBool(dimInactive.ValueRaw); err != nil || !enabled {
+		return protocol.CodeAction{}, false
+	}
+
+	lines := strings.Split(contents, "\n")
+	indentation := lines[line][:len(lines[line])-len(strings.TrimLeft(lines[line], " \t"))]
+	var added strings.Builder
+	for _, name := range dimVariables {
+		def := parser_data.FindVariableDefinitionInSection("Decoration", name)
+		if def == nil || set[prefix+name] {
+			continue
+		}
+		fmt.Fprintf(&added, "\n%s%s%s = %s", indentation, prefix, name, def.Default)
+	}
+	if added.Len() == 0 {
+		return protocol.CodeAction{}, false
+	}
+
+	end := protocol.Position{Line: line, Character: uint32(utf8.RuneCountInString(lines[line]))}
+	return protocol.CodeAction{
+		Title: "Add dim configuration",
+		Kind:  protocol.QuickFix,
+		Edit: &protocol.WorkspaceEdit{
+			Changes: map[protocol.DocumentURI][]protocol.TextEdit{
+				uri: {{Range: collapsedRange(end), NewText: added.String()}},
+			},
+		},
+	}, true
+}
+
+// insertMissingDefaultsAction returns the code action that lists the variables missing from the innermost section block at position,
+// as commented-out assignments to their default values before the block's closing brace.
+// Only variables with a non-trivial default are listed, see hasNonTrivialDefault, and the ones already listed in a comment are left out.
+func insertMissingDefaultsAction(uri protocol.DocumentURI, contents string, document parser.Section, position protocol.Position) (protocol.CodeAction, bool) {
+	var block *parser.Section
+	var path []string
+	var walk func(section parser.Section, sectionPath []string)
+	walk = func(section parser.Section, sectionPath []string) {
+		for _, subsection := range section.Subsections {
+			if int(position.Line) < subsection.Start.Line || int(position.Line) > subsection.End.Line {
+				continue
+			}
+			block, path = &subsection, append(slices.Clone(sectionPath), subsection.Name)
+			walk(subsection, path)
+			return
+		}
+	}
+	walk(document, []string{})
+	if block == nil || block.End.Line <= block.Start.Line {
+		return protocol.CodeAction{}, false
+	}
+
+	def := sectionDefinitionByPath(path)
+	if def == nil {
+		return protocol.CodeAction{}, false
+	}
+
+	lines := strings.Split(contents, "\n")
+	set := make(map[string]bool)
+	for _, assignment := range block.Assignments {
+		set[assignment.Key] = true
+	}
+	// Variables listed by a previous use of this action
+	for _, line := range lines[block.Start.Line:block.End.Line] {
+		if commented, ok := strings.CutPrefix(strings.TrimSpace(line), "#"); ok {
+			name, _, _ := strings.Cut(commented, "=")
+			set[strings.TrimSpace(name)] = true
+		}
+	}
+
+	closingBrace := lines[block.End.Line]
+	indentation := closingBrace[:len(closingBrace)-len(strings.TrimLeft(closingBrace, " \t"))] + parser.FormatIndentation
+	if len(block.Assignments) > 0 {
+		first := lines[block.Assignments[0].Position.Line]
+		indentation = first[:len(first)-len(strings.TrimLeft(first, " \t"))]
+	}
+
+	var missing strings.Builder
+	for _, variable := range def.Variables {
+		if variable.Deprecated || set[variable.Name] || !hasNonTrivialDefault(variable) {
+			continue
+		}
+		fmt.Fprintf(&missing, "%s# %s = %s\n", indentation, variable.Name, variable.Default)
+	}
+	if missing.Len() == 0 {
+		return protocol.CodeAction{}, false
+	}
+
+	return protocol.CodeAction{
+		Title: "Insert missing defaults",
+		Kind:  protocol.RefactorRewrite,
+		Edit: &protocol.WorkspaceEdit{
+			Changes: map[protocol.DocumentURI][]protocol.TextEdit{
+				uri: {{Range: collapsedRange(protocol.Position{Line: uint32(block.End.Line)}), NewText: missing.String()}},
+			},
+		},
+	}, true
+}
+
+// sectionDefinitionByPath returns the definition of the section at path, e.g. [decoration blur]. Names are case-insensitive.
+func sectionDefinitionByPath(path []string) *parser_data.SectionDefinition {
+	sections := parser_data.LoadedSections()
+	var found *parser_data.SectionDefinition
+	for _, name := range path {
+		found = nil
+		for i := range sections {
+			if sections[i].JSONName() == strings.ToLower(name) {
+				found = &sections[i]
+				break
+			}
+		}
+		if found == nil {
+			return nil
+		}
+		sections = found.Subsections
+	}
+	return found
+}
+
+// hasNonTrivialDefault returns true if the variable's default is worth spelling out:
+// one that is documented and is not the zero value of its type, such as 0, false or an empty string.
+func hasNonTrivialDefault(variable parser_data.VariableDefinition) bool {
+	if variable.Default == "" || strings.HasPrefix(variable.Default, "[[") {
+		return false
+	}
+
+	switch variable.Type {
+	case "int", "float", "floatvalue":
+		value, err := strconv.ParseFloat(variable.Default, 64)
+		return err != nil || value != 0
+	case "bool":
+		enabled, err := parser.ParseBool(variable.Default)
+		return err != nil || enabled
+	case "vec2":
+		return variable.Default != "[0, 0]" && variable.Default != "0 0"
+	}
+	return true
+}
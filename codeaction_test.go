@@ -0,0 +1,113 @@
+package hyprls
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ewen-lbh/hyprls/parser"
+	parser_data "github.com/ewen-lbh/hyprls/parser/data"
+	"go.lsp.dev/protocol"
+)
+
+func TestSetToDefaultAction(t *testing.T) {
+	contents := "general {\n  no_border_on_floating # TODO\n  layout\n}\nno_border_on_floating\n"
+	document, err := parser.Parse(contents)
+	if err != nil {
+		t.Fatalf("while parsing: %s", err)
+	}
+
+	action, ok := setToDefaultAction("file:///hyprland.conf", contents, document, 1)
+	if !ok {
+		t.Fatal("expected an action for a variable without a value")
+	}
+
+	edits := action.Edit.Changes["file:///hyprland.conf"]
+	if action.Title != "Set to default: false" || len(edits) != 1 || edits[0].NewText != " = false" || edits[0].Range.Start.Character != 23 {
+		t.Errorf("expected the default value to be appended before the comment, got %q with %#v", action.Title, edits)
+	}
+
+	if _, ok := setToDefaultAction("file:///hyprland.conf", contents, document, 4); ok {
+		t.Error("expected no action outside of a section")
+	}
+}
+
+func TestAddDimConfigurationAction(t *testing.T) {
+	contents := "decoration {\n    dim_inactive = true\n    dim_special = 0.3\n}\ndecoration:dim_inactive = yes\n"
+	document, err := parser.Parse(contents)
+	if err != nil {
+		t.Fatalf("while parsing: %s", err)
+	}
+
+	action, ok := addDimConfigurationAction("file:///hyprland.conf", contents, document, 1)
+	if !ok {
+		t.Fatal("expected an action on dim_inactive = true")
+	}
+	edits := action.Edit.Changes["file:///hyprland.conf"]
+	if action.Title != "Add dim configuration" || len(edits) != 1 || edits[0].NewText != "\n    dim_strength = 0.5" || edits[0].Range.Start.Character != 23 {
+		t.Errorf("expected dim_strength to be added below dim_inactive, got %q with %#v", action.Title, edits)
+	}
+
+	action, ok = addDimConfigurationAction("file:///hyprland.conf", contents, document, 4)
+	if !ok {
+		t.Fatal("expected an action on decoration:dim_inactive = yes")
+	}
+	if edits := action.Edit.Changes["file:///hyprland.conf"]; edits[0].NewText != "\ndecoration:dim_strength = 0.5\ndecoration:dim_special = 0.2" {
+		t.Errorf("expected full-path assignments at the root of the document, got %#v", edits)
+	}
+
+	disabled := "decoration {\n    dim_inactive = false\n}\n"
+	if document, err = parser.Parse(disabled); err != nil {
+		t.Fatalf("while parsing: %s", err)
+	}
+	if _, ok := addDimConfigurationAction("file:///hyprland.conf", disabled, document, 1); ok {
+		t.Error("expected no action when dim_inactive is disabled")
+	}
+}
+
+func TestInsertMissingDefaultsAction(t *testing.T) {
+	contents := "decoration {\n  rounding = 5\n  blur {\n      size = 3\n      # passes = 1\n  }\n}\n"
+	document, err := parser.Parse(contents)
+	if err != nil {
+		t.Fatalf("while parsing: %s", err)
+	}
+
+	action, ok := insertMissingDefaultsAction("file:///hyprland.conf", contents, document, protocol.Position{Line: 3, Character: 8})
+	if !ok {
+		t.Fatal("expected an action in the blur section")
+	}
+
+	edits := action.Edit.Changes["file:///hyprland.conf"]
+	if action.Title != "Insert missing defaults" || len(edits) != 1 || edits[0].Range.Start != (protocol.Position{Line: 5}) {
+		t.Fatalf("expected the defaults to be inserted before the closing brace of blur, got %q with %#v", action.Title, edits)
+	}
+
+	inserted := edits[0].NewText
+	if !strings.Contains(inserted, "      # enabled = true\n") {
+		t.Errorf("expected the default of enabled to be listed with the indentation of the block, got %q", inserted)
+	}
+	for _, unexpected := range []string{"size", "passes", "xray"} {
+		if strings.Contains(inserted, "# "+unexpected) {
+			t.Errorf("expected %s not to be listed, got %q", unexpected, inserted)
+		}
+	}
+}
+
+func TestHasNonTrivialDefault(t *testing.T) {
+	for _, c := range []struct {
+		variable parser_data.VariableDefinition
+		expected bool
+	}{
+		{parser_data.VariableDefinition{Type: "int", Default: "0"}, false},
+		{parser_data.VariableDefinition{Type: "int", Default: "5"}, true},
+		{parser_data.VariableDefinition{Type: "float", Default: "0.0"}, false},
+		{parser_data.VariableDefinition{Type: "bool", Default: "false"}, false},
+		{parser_data.VariableDefinition{Type: "bool", Default: "true"}, true},
+		{parser_data.VariableDefinition{Type: "str", Default: "[[Empty]]"}, false},
+		{parser_data.VariableDefinition{Type: "vec2", Default: "[0, 0]"}, false},
+		{parser_data.VariableDefinition{Type: "gradient", Default: "0xffffffff"}, true},
+	} {
+		if actual := hasNonTrivialDefault(c.variable); actual != c.expected {
+			t.Errorf("hasNonTrivialDefault(%#v) = %v, expected %v", c.variable, actual, c.expected)
+		}
+	}
+}
@@ -90,6 +90,70 @@ func encodeColorLiteral(color protocol.Color) string {
 	return out
 }
 
+// colorLiteralsAt returns the color literals of the document whose range contains the given position, along with their range.
+// Colors of gradients are returned one by one.
+func colorLiteralsAt(document parser.Section, position protocol.Position) []protocol.Range {
+	ranges := make([]protocol.Range, 0)
+	document.WalkValues(func(a *parser.Assignment, v *parser.Value) {
+		candidates := []parser.Value{*v}
+		if v.Kind == parser.Gradient {
+			candidates = v.Gradient.Stops
+		}
+
+		for _, candidate := range candidates {
+			if candidate.Kind == parser.Color && within(candidate.LSPRange(), position) {
+				ranges = append(ranges, candidate.LSPRange())
+			}
+		}
+	})
+	return ranges
+}
+
+// convertColorLiteral converts a 0xAARRGGBB literal to rgba(RRGGBBAA) and the other way around, preserving the alpha channel.
+// rgb(RRGGBB) literals are converted to 0xAARRGGBB too, with an opaque alpha channel.
+func convertColorLiteral(raw string) (converted string, ok bool) {
+	c, err := parser.ParseColor(raw)
+	if err != nil {
+		return "", false
+	}
+
+	switch {
+	case strings.HasPrefix(raw, "0x"):
+		return fmt.Sprintf("rgba(%02x%02x%02x%02x)", c.R, c.G, c.B, c.A), true
+	case strings.HasPrefix(raw, "rgba("), strings.HasPrefix(raw, "rgb("):
+		return fmt.Sprintf("0x%02x%02x%02x%02x", c.A, c.R, c.G, c.B), true
+	}
+	return "", false
+}
+
+// convertColorLiteralActions returns the code actions converting the color literals under the cursor to their other form, see convertColorLiteral
+func convertColorLiteralActions(uri protocol.DocumentURI, contents string, document parser.Section, position protocol.Position) []protocol.CodeAction {
+	lines := strings.Split(contents, "\n")
+	actions := make([]protocol.CodeAction, 0)
+	for _, literalRange := range colorLiteralsAt(document, position) {
+		line := []rune(lines[literalRange.Start.Line])
+		if int(literalRange.End.Character) > len(line) {
+			continue
+		}
+
+		converted, ok := convertColorLiteral(string(line[literalRange.Start.Character:literalRange.End.Character]))
+		if !ok {
+			continue
+		}
+
+		actions = append(actions, protocol.CodeAction{
+			Title: fmt.Sprintf("Convert to %s", converted),
+			Kind:  protocol.RefactorRewrite,
+			Edit: &protocol.WorkspaceEdit{
+				Changes: map[protocol.DocumentURI][]protocol.TextEdit{
+					uri: {{Range: literalRange, NewText: converted}},
+				},
+			},
+		})
+	}
+	return actions
+}
+
 func roundToThree(f float64) float64 {
 	return math.Round(f*1_00) / 1_00
 }
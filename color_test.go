@@ -4,6 +4,7 @@ import (
 	"math/rand"
 	"testing"
 
+	"github.com/ewen-lbh/hyprls/parser"
 	"go.lsp.dev/protocol"
 	"go.uber.org/zap"
 )
@@ -55,6 +56,36 @@ func TestColorDecoding(t *testing.T) {
 	}
 }
 
+func TestConvertColorLiteral(t *testing.T) {
+	for raw, expected := range map[string]string{
+		"0x80ff0000":     "rgba(ff000080)",
+		"rgba(ff000080)": "0x80ff0000",
+		"rgb(00ff00)":    "0xff00ff00",
+	} {
+		if converted, ok := convertColorLiteral(raw); !ok || converted != expected {
+			t.Errorf("expected %s to be converted to %s, got %q (ok=%v)", raw, expected, converted, ok)
+		}
+	}
+}
+
+func TestConvertColorLiteralActionsInGradients(t *testing.T) {
+	contents := "general {\n  col.active_border = rgba(11ee11ff) 0xff00ff00 45deg\n}\n"
+	document, err := parser.Parse(contents)
+	if err != nil {
+		t.Fatalf("while parsing: %s", err)
+	}
+
+	actions := convertColorLiteralActions("file:///hyprland.conf", contents, document, protocol.Position{Line: 1, Character: 40})
+	if len(actions) != 1 {
+		t.Fatalf("expected a single action, got %#v", actions)
+	}
+
+	edit := actions[0].Edit.Changes["file:///hyprland.conf"][0]
+	if edit.NewText != "rgba(00ff00ff)" || edit.Range.Start.Character != 37 || edit.Range.End.Character != 47 {
+		t.Errorf("expected only the second color to be converted, got %#v", edit)
+	}
+}
+
 func compareColorStructs(a, b protocol.Color) bool {
 	delta := 0
 	delta += int(a.Red*255 - b.Red*255)
@@ -4,9 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"regexp"
+	"slices"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 
+	"github.com/ewen-lbh/hyprls/config"
 	"github.com/ewen-lbh/hyprls/parser"
 	parser_data "github.com/ewen-lbh/hyprls/parser/data"
 	"go.lsp.dev/protocol"
@@ -14,6 +18,7 @@ import (
 )
 
 func (h Handler) Completion(ctx context.Context, params *protocol.CompletionParams) (*protocol.CompletionList, error) {
+	parser_data.EnsureLoaded()
 	line, err := currentLine(params.TextDocument.URI, params.Position)
 	if err != nil {
 		return nil, nil
@@ -29,20 +34,120 @@ func (h Handler) Completion(ctx context.Context, params *protocol.CompletionPara
 		sec = &parser.Section{}
 	}
 
-	cursorIsAfterEquals := err == nil && strings.Contains(line, "=") && strings.Index(line, "=") < int(params.Position.Character)
+	cursorIsAfterEquals := cursorIsInValue(line, params.Position)
+
+	// A { was just typed: only propose what can go in the section that was just opened
+	if !cursorIsAfterEquals && params.Context != nil && params.Context.TriggerKind == protocol.CompletionTriggerKindTriggerCharacter && params.Context.TriggerCharacter == "{" {
+		openedSectionName := strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(line), "{"))
+		return &protocol.CompletionList{
+			Items: subsectionCompletions(parser_data.FindSectionDefinitionByName(openedSectionName), parser.Section{}),
+		}, nil
+	}
+
+	// A . was typed in a value, e.g. a decimal number: there is nothing to propose
+	if cursorIsAfterEquals && params.Context != nil && params.Context.TriggerKind == protocol.CompletionTriggerKindTriggerCharacter && params.Context.TriggerCharacter == "." {
+		return nil, nil
+	}
 
 	// we are after the equals sign, suggest custom properties only
 	if cursorIsAfterEquals {
+		key := strings.TrimSpace(strings.Split(line, "=")[0])
+		if kw, found := parser_data.FindKeyword(key); found && kw.Name == "bind" {
+			if items, ok := submapCompletions(params.TextDocument.URI, line, params.Position); ok {
+				return &protocol.CompletionList{
+					Items: items,
+				}, nil
+			}
+			if items, ok := bindCompletions(line, params.Position); ok {
+				typed := typedWord(textBeforeCursor(line, params.Position), func(r rune) bool {
+					return r == '$' || r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+				})
+				file.WalkCustomVariables(func(v *parser.CustomVariable) {
+					item := wordCompletion("$"+v.Key, protocol.CompletionItemKindVariable, typed, params.Position)
+					item.Documentation = protocol.MarkupContent{
+						Kind:  protocol.PlainText,
+						Value: v.ValueRaw,
+					}
+					items = append(items, item)
+				})
+				return &protocol.CompletionList{
+					Items: items,
+				}, nil
+			}
+		}
+
+		switch key {
+		case "workspace":
+			if items, ok := workspaceRuleCompletions(line, params.Position); ok {
+				return &protocol.CompletionList{
+					Items: items,
+				}, nil
+			}
+		case "animation":
+			if items, ok := bezierCompletions(params.TextDocument.URI, line, params.Position); ok {
+				return &protocol.CompletionList{
+					Items: items,
+				}, nil
+			}
+			if items, ok := animationCompletions(line, params.Position); ok {
+				return &protocol.CompletionList{
+					Items: items,
+				}, nil
+			}
+		case "source":
+			if items, ok := sourcePathCompletions(params.TextDocument.URI, line, params.Position); ok {
+				return &protocol.CompletionList{
+					Items: items,
+				}, nil
+			}
+		case "layerrule":
+			if items, ok := ruleCompletions(parser_data.LayerRules, line, params.Position); ok {
+				return &protocol.CompletionList{
+					Items: items,
+				}, nil
+			}
+		case "windowrule":
+			if items, ok := windowRuleCompletions(line, params.Position); ok {
+				return &protocol.CompletionList{
+					Items: items,
+				}, nil
+			}
+		case "windowrulev2":
+			if items, ok := windowRuleCompletions(line, params.Position); ok {
+				return &protocol.CompletionList{
+					Items: items,
+				}, nil
+			}
+			if items, ok := windowRuleFilterCompletions(line, params.Position); ok {
+				return &protocol.CompletionList{
+					Items: items,
+				}, nil
+			}
+		case "monitor":
+			if items, ok := monitorDescriptionCompletions(ctx, line, params.Position); ok {
+				return &protocol.CompletionList{
+					Items: items,
+				}, nil
+			}
+			if items, ok := monitorKeywordCompletions(line, params.Position); ok {
+				return &protocol.CompletionList{
+					Items: items,
+				}, nil
+			}
+		}
+
 		items := make([]protocol.CompletionItem, 0)
 
-		cursorOrLineEnd := min(int(params.Position.Character), len(line)-1)
-		characterBeforeCursorIsDollarSign := line[cursorOrLineEnd] == '$'
+		characterBeforeCursor, _ := utf8.DecodeLastRuneInString(textBeforeCursor(line, params.Position))
+		characterBeforeCursorIsDollarSign := characterBeforeCursor == '$'
 
 		// Don't propose custom variables if in the middle of typing a word
 		// Only propose if a dollar sign was typed or is just before the cursor
-		// Or we are after whitespace
+		// Or we are after whitespace or right after the equals sign
 		// Or we are in the middle of a color completion (typed a r, and key is a color or gradient)
-		if !characterBeforeCursorIsDollarSign && !unicode.IsSpace(rune(line[params.Position.Character-1])) {
+		// Unless completion was explicitly invoked
+		explicitlyInvoked := params.Context != nil && params.Context.TriggerKind == protocol.CompletionTriggerKindInvoked
+		if !explicitlyInvoked && !characterBeforeCursorIsDollarSign && !unicode.IsSpace(characterBeforeCursor) && characterBeforeCursor != '=' {
 			return nil, nil
 		}
 
@@ -50,8 +155,8 @@ func (h Handler) Completion(ctx context.Context, params *protocol.CompletionPara
 		var textEditRange protocol.Range
 		if characterBeforeCursorIsDollarSign {
 			textEditRange = protocol.Range{
-				protocol.Position{params.Position.Line, params.Position.Character - 1},
-				protocol.Position{params.Position.Line, params.Position.Character},
+				Start: protocol.Position{Line: params.Position.Line, Character: params.Position.Character - 1},
+				End:   protocol.Position{Line: params.Position.Line, Character: params.Position.Character},
 			}
 		} else {
 			textEditRange = collapsedRange(params.Position)
@@ -80,8 +185,9 @@ func (h Handler) Completion(ctx context.Context, params *protocol.CompletionPara
 		}
 
 		valueKind := parser.String
+		var assignment *parser_data.VariableDefinition
 		if sec != nil {
-			assignment := currentAssignment(*sec, params.Position)
+			assignment = currentAssignment(*sec, params.Position)
 			if assignment != nil {
 				valueKind, err = parser.ValueKindFromString(assignment.ParserTypeString())
 				if err != nil {
@@ -120,14 +226,10 @@ func (h Handler) Completion(ctx context.Context, params *protocol.CompletionPara
 				Documentation:    "Define a color of the form 0xAARRGGBB in hexadecimal notation.",
 			})
 		case parser.Bool:
-			items = append(items, protocol.CompletionItem{
-				Label: "true",
-				Kind:  protocol.CompletionItemKindValue,
-			})
-			items = append(items, protocol.CompletionItem{
-				Label: "false",
-				Kind:  protocol.CompletionItemKindValue,
-			})
+			for _, item := range enumValueCompletions(assignment, []string{"true", "false", "yes", "no", "on", "off", "1", "0"}) {
+				item.Detail = "Hyprland treats true, yes, on and 1 the same way, and false, no, off and 0 too"
+				items = append(items, item)
+			}
 		case parser.Modmask:
 			for keystring := range parser.ModKeyNames {
 				items = append(items, protocol.CompletionItem{
@@ -135,7 +237,12 @@ func (h Handler) Completion(ctx context.Context, params *protocol.CompletionPara
 					Kind:  protocol.CompletionItemKindEnumMember,
 				})
 			}
-
+		default:
+			if assignment != nil {
+				items = append(items, enumValueCompletions(assignment, assignment.EnumValues)...)
+				items = append(items, valueSuggestionCompletions(assignment)...)
+				items = append(items, noneValueCompletions(assignment)...)
+			}
 		}
 
 		return &protocol.CompletionList{
@@ -144,16 +251,34 @@ func (h Handler) Completion(ctx context.Context, params *protocol.CompletionPara
 	}
 
 	availableVariables := make([]parser_data.VariableDefinition, 0)
-	if sec != nil {
-		secDef := parser_data.FindSectionDefinitionByName(sec.Name)
-		if secDef != nil {
-			availableVariables = append(availableVariables, secDef.Variables...)
-		}
+	secDef := parser_data.FindSectionDefinitionByName(sec.Name)
+	if secDef != nil {
+		availableVariables = append(availableVariables, secDef.AllVariables()...)
 	}
 
+	// Variables with dotted names, such as col.active_border, are completed as a whole so that typing col. proposes the rest of their names.
+	// The same goes for variables of subsections, such as blur:enabled.
+	typedKey := typedWord(textBeforeCursor(line, params.Position), func(r rune) bool {
+		return r == '.' || r == ':' || r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+	})
+	typingDottedKey := strings.Contains(typedKey, ".")
+
+	pinnedVersion, versionIsPinned := pinnedHyprlandVersion(params.TextDocument.URI)
 	items := make([]protocol.CompletionItem, 0)
 vars:
 	for _, vardef := range availableVariables {
+		if vardef.Deprecated {
+			continue
+		}
+
+		if typingDottedKey && !strings.HasPrefix(vardef.Name, typedKey) {
+			continue
+		}
+
+		if versionIsPinned && !vardef.AvailableIn(pinnedVersion) {
+			continue
+		}
+
 		// Don't suggest variables that are already defined
 		for _, definedvar := range sec.Assignments {
 			if vardef.Name == definedvar.Key {
@@ -161,51 +286,482 @@ vars:
 			}
 		}
 
+		item := wordCompletion(vardef.Name, protocol.CompletionItemKindField, typedKey, params.Position)
+		// Accept the completion and go on with the assignment in a single keystroke
+		item.CommitCharacters = []string{" ", "="}
+		item.Documentation = protocol.MarkupContent{
+			Kind:  protocol.Markdown,
+			Value: fmt.Sprintf("Type: %s\n\n%s", vardef.PrettyType(), vardef.Description),
+		}
+		items = append(items, item)
+	}
+
+	// Keywords and subsections don't have dots in their names
+	if typingDottedKey {
+		return &protocol.CompletionList{
+			Items: items,
+		}, nil
+	}
+
+	atTopLevel := sec.Start == file.Start && sec.End == file.End
+	for _, kw := range parser_data.Keywords {
+		// Proposed as a snippet along with common autostart applications, see execOnceCompletions
+		if atTopLevel && kw.Name == "exec-once" {
+			continue
+		}
+
 		items = append(items, protocol.CompletionItem{
-			Label: vardef.Name,
-			Kind:  protocol.CompletionItemKindField,
+			Label: kw.Name,
+			Kind:  protocol.CompletionItemKindKeyword,
 			Documentation: protocol.MarkupContent{
 				Kind:  protocol.Markdown,
-				Value: fmt.Sprintf("Type: %s\n\n%s", vardef.Type, vardef.Description),
+				Value: kw.Description,
 			},
 		})
+		if len(kw.Parameters) > 0 {
+			items = append(items, keywordSnippetCompletion(kw))
+		}
 	}
 
-	for _, kw := range parser_data.Keywords {
+	if atTopLevel {
+		items = append(items, execOnceCompletions()...)
+	}
+	items = append(items, subsectionCompletions(secDef, *sec)...)
+
+	return &protocol.CompletionList{
+		Items: items,
+	}, nil
+}
+
+// autostartApplications are commonly started with exec-once, with what they are
+var autostartApplications = []parser_data.ValueSuggestion{
+	{Value: "waybar", Label: "status bar"},
+	{Value: "swaync", Label: "notification center"},
+	{Value: "dunst", Label: "notification daemon"},
+	{Value: "mako", Label: "notification daemon"},
+	{Value: "nm-applet --indicator", Label: "network manager tray icon"},
+	{Value: "blueman-applet", Label: "bluetooth tray icon"},
+	{Value: "hyprpaper", Label: "wallpaper utility"},
+	{Value: "hypridle", Label: "idle daemon"},
+	{Value: "wl-paste --watch cliphist store", Label: "clipboard history"},
+}
+
+// execOnceCompletions proposes the exec-once keyword as a snippet, and ready-made exec-once lines for autostartApplications
+func execOnceCompletions() []protocol.CompletionItem {
+	keyword, _ := parser_data.FindKeyword("exec-once")
+	items := []protocol.CompletionItem{
+		{
+			Label:            "exec-once",
+			Kind:             protocol.CompletionItemKindKeyword,
+			InsertTextFormat: protocol.InsertTextFormatSnippet,
+			InsertText:       "exec-once = $0",
+			Documentation: protocol.MarkupContent{
+				Kind:  protocol.Markdown,
+				Value: keyword.Description,
+			},
+		},
+	}
+
+	for _, application := range autostartApplications {
 		items = append(items, protocol.CompletionItem{
-			Label: kw.Name,
-			Kind:  protocol.CompletionItemKindKeyword,
+			Label:            "exec-once = " + application.Value,
+			Kind:             protocol.CompletionItemKindSnippet,
+			Detail:           "Start " + application.Label + " with Hyprland",
+			InsertTextFormat: protocol.InsertTextFormatPlainText,
+			InsertText:       "exec-once = " + application.Value,
+		})
+	}
+	return items
+}
+
+// keywordSnippetCompletion proposes a line using the keyword with a tab stop for each of its parameters,
+// e.g. bind = ${1:mods}, ${2:key}, ${3:dispatcher}, ${4:args}
+func keywordSnippetCompletion(keyword parser_data.KeywordDefinition) protocol.CompletionItem {
+	names := make([]string, 0, len(keyword.Parameters))
+	placeholders := make([]string, 0, len(keyword.Parameters))
+	for i, parameter := range keyword.Parameters {
+		names = append(names, parameter.Name)
+		placeholders = append(placeholders, fmt.Sprintf("${%d:%s}", i+1, parameter.Name))
+	}
+
+	return protocol.CompletionItem{
+		Label:            fmt.Sprintf("%s = %s", keyword.Name, strings.Join(names, ", ")),
+		Kind:             protocol.CompletionItemKindSnippet,
+		FilterText:       keyword.Name,
+		InsertTextFormat: protocol.InsertTextFormatSnippet,
+		InsertText:       fmt.Sprintf("%s = %s", keyword.Name, strings.Join(placeholders, ", ")),
+		Documentation: protocol.MarkupContent{
+			Kind:  protocol.Markdown,
+			Value: keyword.Description,
+		},
+	}
+}
+
+// cursorIsInValue returns true if the cursor is on the right-hand side of an assignment, e.g. general:layout = |,
+// where only values make sense, as opposed to the start of a line where variables, keywords and sections are proposed.
+func cursorIsInValue(line string, position protocol.Position) bool {
+	equals := strings.Index(line, "=")
+	return equals >= 0 && utf8.RuneCountInString(line[:equals]) < int(position.Character)
+}
+
+// enumValueCompletions proposes the given values for the variable, with the variable's description as documentation.
+// Values that are also suggested by the variable get the suggestion's label, see valueLabelDetails.
+func enumValueCompletions(variable *parser_data.VariableDefinition, values []string) []protocol.CompletionItem {
+	documentation := ""
+	if variable != nil {
+		documentation = variable.Description
+	}
+
+	items := make([]protocol.CompletionItem, 0, len(values))
+	for _, value := range values {
+		item := protocol.CompletionItem{
+			Label: value,
+			Kind:  protocol.CompletionItemKindEnumMember,
 			Documentation: protocol.MarkupContent{
 				Kind:  protocol.Markdown,
-				Value: kw.Description,
+				Value: documentation,
 			},
+		}
+		if variable != nil {
+			for _, suggestion := range variable.Suggestions {
+				if suggestion.Value == value {
+					item.Data = valueLabelDetails(suggestion)
+				}
+			}
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// valueLabelDetails shows the label of the suggested value next to it, see withLabelDetails
+func valueLabelDetails(suggestion parser_data.ValueSuggestion) *CompletionItemLabelDetails {
+	return &CompletionItemLabelDetails{
+		Detail:      " " + suggestion.Label,
+		Description: fmt.Sprintf("%q", suggestion.Value),
+	}
+}
+
+var noneValuePattern = regexp.MustCompile(`\bnone\b`)
+
+// noneValueCompletions proposes none for string variables whose description names none as a value.
+// Variables that enumerate their values get them proposed instead, see enumValueCompletions.
+func noneValueCompletions(variable *parser_data.VariableDefinition) []protocol.CompletionItem {
+	if variable.Type != "str" && variable.Type != "string" {
+		return nil
+	}
+
+	if !noneValuePattern.MatchString(variable.Description) || len(variable.EnumValues) > 0 {
+		return nil
+	}
+
+	for _, suggestion := range variable.Suggestions {
+		if suggestion.Value == "none" {
+			return nil
+		}
+	}
+
+	return []protocol.CompletionItem{
+		{
+			Label:  "none",
+			Kind:   protocol.CompletionItemKindValue,
+			Detail: "Disable " + variable.Name,
+		},
+	}
+}
+
+// valueSuggestionCompletions proposes the noteworthy values of the variable, see parser_data.ValueSuggestion.
+// Values the variable enumerates are left to enumValueCompletions.
+func valueSuggestionCompletions(variable *parser_data.VariableDefinition) []protocol.CompletionItem {
+	items := make([]protocol.CompletionItem, 0, len(variable.Suggestions))
+	for i, suggestion := range variable.Suggestions {
+		if slices.Contains(variable.EnumValues, suggestion.Value) {
+			continue
+		}
+
+		items = append(items, protocol.CompletionItem{
+			Label:  suggestion.Value,
+			Kind:   protocol.CompletionItemKindValue,
+			Detail: suggestion.Label,
+			Data:   valueLabelDetails(suggestion),
+			Documentation: protocol.MarkupContent{
+				Kind:  protocol.Markdown,
+				Value: variable.Description,
+			},
+			// Keep the suggestions in the order they were written in
+			SortText: fmt.Sprintf("%03d", i),
 		})
 	}
+	return items
+}
+
+// subsectionCompletions proposes the subsections of secDef that are not already opened in sec
+func subsectionCompletions(secDef *parser_data.SectionDefinition, sec parser.Section) []protocol.CompletionItem {
+	items := make([]protocol.CompletionItem, 0)
+	if secDef == nil {
+		return items
+	}
 
 subsections:
-	for _, subsections := range sec.Subsections {
+	for _, subsection := range secDef.Subsections {
 		// Don't suggest subsections that are already defined
 		for _, definedSubsection := range sec.Subsections {
-			if subsections.Name == definedSubsection.Name {
+			if subsection.JSONName() == strings.ToLower(definedSubsection.Name) {
 				continue subsections
 			}
 		}
 
 		items = append(items, protocol.CompletionItem{
-			Label: subsections.Name,
+			Label: subsection.JSONName(),
 			Kind:  protocol.CompletionItemKindModule,
 			Documentation: protocol.MarkupContent{
 				Kind:  protocol.Markdown,
-				Value: fmt.Sprintf("Subsection of %s", sec.Name),
+				Value: fmt.Sprintf("Subsection of %s", secDef.JSONName()),
 			},
 		})
 	}
-
-	return &protocol.CompletionList{
-		Items: items,
-	}, nil
+	return items
 }
 
 func (h Handler) CompletionResolve(ctx context.Context, params *protocol.CompletionItem) (*protocol.CompletionItem, error) {
 	return nil, errors.New("unimplemented")
 }
+
+// bindCompletions proposes completions for the field of the bind = MODS, key, dispatcher, params line the cursor is in.
+// ok is false if the cursor is in the dispatcher's params, unless the dispatcher takes a workspace.
+func bindCompletions(line string, position protocol.Position) (items []protocol.CompletionItem, ok bool) {
+	beforeCursor := textBeforeCursor(line, position)
+	field, ok := argumentIndex(line, position)
+	if !ok {
+		return nil, false
+	}
+	items = make([]protocol.CompletionItem, 0)
+
+	switch field {
+	case 0:
+		typed := typedWord(beforeCursor, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) })
+		modifiers := make([]string, 0, len(parser.ModKeyNames))
+		for name := range parser.ModKeyNames {
+			modifiers = append(modifiers, name)
+		}
+		slices.Sort(modifiers)
+		for _, name := range modifiers {
+			items = append(items, wordCompletion(name, protocol.CompletionItemKindEnumMember, typed, position))
+		}
+		for _, combination := range parser_data.ModifierCombinations {
+			items = append(items, wordCompletion(combination, protocol.CompletionItemKindEnumMember, typed, position))
+		}
+	case 1:
+		typed := typedWord(beforeCursor, func(r rune) bool { return !unicode.IsSpace(r) && r != ',' })
+		for _, name := range parser_data.KeyNames {
+			items = append(items, wordCompletion(name, protocol.CompletionItemKindConstant, typed, position))
+		}
+	case 2:
+		typed := typedWord(beforeCursor, func(r rune) bool { return !unicode.IsSpace(r) && r != ',' })
+		dispatchers := parser_data.Dispatchers
+		// Mouse binds only work with the dispatchers that follow the mouse
+		if strings.Contains(strings.TrimPrefix(config.LineKey(line), "bind"), "m") {
+			dispatchers = parser_data.MouseDispatchers
+		}
+		for _, dispatcher := range dispatchers {
+			item := wordCompletion(dispatcher.Name, protocol.CompletionItemKindFunction, typed, position)
+			item.Detail = "Params: " + dispatcher.Params
+			item.Documentation = protocol.MarkupContent{
+				Kind:  protocol.Markdown,
+				Value: dispatcher.Description,
+			}
+			// Rank built-in dispatchers before anything else, e.g. variables that might hold a plugin's dispatcher
+			item.SortText = "0" + dispatcher.Name
+			items = append(items, item)
+		}
+	case 3:
+		arguments := lineArguments(line, int(position.Line))
+		if len(arguments) <= 2 {
+			return nil, false
+		}
+		dispatcher, found := parser_data.FindDispatcher(arguments[2].Value)
+		if !found || !strings.HasPrefix(dispatcher.Params, "workspace") {
+			return nil, false
+		}
+
+		typed := typedWord(beforeCursor, func(r rune) bool { return !unicode.IsSpace(r) && r != ',' })
+		for i, selector := range parser_data.WorkspaceSelectors {
+			item := wordCompletion(selector.Value, protocol.CompletionItemKindValue, typed, position)
+			item.Detail = selector.Description
+			item.SortText = fmt.Sprintf("%03d", i)
+			items = append(items, item)
+		}
+	default:
+		return nil, false
+	}
+	return items, true
+}
+
+// typedWord returns the word that is being typed before the cursor, made of runes satisfying isPartOfWord
+func typedWord(beforeCursor string, isPartOfWord func(rune) bool) string {
+	return beforeCursor[strings.LastIndexFunc(beforeCursor, func(r rune) bool { return !isPartOfWord(r) })+1:]
+}
+
+// wordCompletion returns a completion item that replaces the typed part of the word with label
+func wordCompletion(label string, kind protocol.CompletionItemKind, typed string, position protocol.Position) protocol.CompletionItem {
+	return protocol.CompletionItem{
+		Label: label,
+		Kind:  kind,
+		TextEdit: &protocol.TextEdit{
+			Range: protocol.Range{
+				Start: protocol.Position{Line: position.Line, Character: position.Character - uint32(utf8.RuneCountInString(typed))},
+				End:   position,
+			},
+			NewText: label,
+		},
+	}
+}
+
+// bezierCompletions proposes the defined bezier curves when the cursor is in the CURVE argument of an animation = ... line.
+// ok is false if the cursor is in another argument.
+func bezierCompletions(uri protocol.URI, line string, position protocol.Position) (items []protocol.CompletionItem, ok bool) {
+	if field, ok := argumentIndex(line, position); !ok || field != 3 {
+		return nil, false
+	}
+
+	beforeCursor := textBeforeCursor(line, position)
+
+	typed := typedWord(beforeCursor, func(r rune) bool { return !unicode.IsSpace(r) && r != ',' })
+	names := make([]string, 0)
+	for name := range definedBeziers(uri) {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	items = make([]protocol.CompletionItem, 0, len(names))
+	for _, name := range names {
+		items = append(items, wordCompletion(name, protocol.CompletionItemKindValue, typed, position))
+	}
+	return items, true
+}
+
+// animationCompletions proposes animation names in the NAME argument of an animation = ... line,
+// and the styles the animation supports in its STYLE argument.
+// ok is false if the cursor is in another argument.
+func animationCompletions(line string, position protocol.Position) (items []protocol.CompletionItem, ok bool) {
+	beforeCursor := textBeforeCursor(line, position)
+	typed := typedWord(beforeCursor, func(r rune) bool { return !unicode.IsSpace(r) && r != ',' })
+
+	field, ok := argumentIndex(line, position)
+	if !ok {
+		return nil, false
+	}
+	switch field {
+	case 0:
+		items = make([]protocol.CompletionItem, 0, len(parser_data.Animations))
+		for _, animation := range parser_data.Animations {
+			item := wordCompletion(animation.Name, protocol.CompletionItemKindEnumMember, typed, position)
+			item.Detail = animation.Description
+			if animation.Parent != "" {
+				item.Documentation = protocol.MarkupContent{
+					Kind:  protocol.Markdown,
+					Value: fmt.Sprintf("Inherits from `%s` when unset", animation.Parent),
+				}
+			}
+			items = append(items, item)
+		}
+		return items, true
+	case 4:
+		arguments := lineArguments(line, int(position.Line))
+		animation, found := parser_data.FindAnimation(arguments[0].Value)
+		if !found {
+			return nil, false
+		}
+
+		items = make([]protocol.CompletionItem, 0, len(animation.Styles))
+		for _, style := range animation.Styles {
+			items = append(items, wordCompletion(style, protocol.CompletionItemKindEnumMember, typed, position))
+		}
+		return items, true
+	}
+	return nil, false
+}
+
+// workspaceRuleCompletions proposes workspace rules when the cursor is after a comma in a workspace = NAME, RULES... line.
+// ok is false if the cursor is not where a rule name is expected.
+func workspaceRuleCompletions(line string, position protocol.Position) (items []protocol.CompletionItem, ok bool) {
+	if field, ok := argumentIndex(line, position); !ok || field == 0 {
+		return nil, false
+	}
+
+	beforeCursor := textBeforeCursor(line, position)
+	lastComma := strings.LastIndex(beforeCursor, ",")
+
+	typedRule := strings.TrimLeftFunc(beforeCursor[lastComma+1:], unicode.IsSpace)
+	if strings.Contains(typedRule, ":") {
+		return nil, false
+	}
+
+	// Replace what was already typed of the rule's name
+	textEditRange := protocol.Range{
+		Start: protocol.Position{Line: position.Line, Character: position.Character - uint32(utf8.RuneCountInString(typedRule))},
+		End:   position,
+	}
+
+	items = make([]protocol.CompletionItem, 0, len(parser_data.WorkspaceRules))
+	for _, rule := range parser_data.WorkspaceRules {
+		items = append(items, protocol.CompletionItem{
+			Label: rule.Name,
+			Kind:  protocol.CompletionItemKindProperty,
+			Documentation: protocol.MarkupContent{
+				Kind:  protocol.Markdown,
+				Value: fmt.Sprintf("Type: %s\n\n%s", rule.Type, rule.Description),
+			},
+			TextEdit: &protocol.TextEdit{
+				Range:   textEditRange,
+				NewText: rule.Name + ":",
+			},
+		})
+	}
+	return items, true
+}
+
+// monitorDescriptionCompletions proposes descriptions of connected monitors when the cursor is after monitor = desc:
+// ok is false if the cursor is not in a desc: monitor name.
+func monitorDescriptionCompletions(ctx context.Context, line string, position protocol.Position) (items []protocol.CompletionItem, ok bool) {
+	if field, ok := argumentIndex(line, position); !ok || field != 0 {
+		return nil, false
+	}
+
+	beforeCursor := textBeforeCursor(line, position)
+	_, value, _ := strings.Cut(beforeCursor, "=")
+	typedDescription, isDescription := strings.CutPrefix(strings.TrimLeftFunc(value, unicode.IsSpace), "desc:")
+	if !isDescription {
+		return nil, false
+	}
+
+	monitors, err := hyprctlMonitors(ctx)
+	if err != nil {
+		logger.Debug("while getting monitors for completion", zap.Error(err))
+		return nil, true
+	}
+
+	// Replace what was already typed of the description
+	textEditRange := protocol.Range{
+		Start: protocol.Position{Line: position.Line, Character: position.Character - uint32(utf8.RuneCountInString(typedDescription))},
+		End:   position,
+	}
+
+	items = make([]protocol.CompletionItem, 0, len(monitors))
+	for _, monitor := range monitors {
+		if !strings.HasPrefix(strings.ToLower(monitor.Description), strings.ToLower(typedDescription)) {
+			continue
+		}
+
+		items = append(items, protocol.CompletionItem{
+			Label:  monitor.Description,
+			Kind:   protocol.CompletionItemKindValue,
+			Detail: monitor.Name,
+			TextEdit: &protocol.TextEdit{
+				Range:   textEditRange,
+				NewText: monitor.Description,
+			},
+		})
+	}
+	return items, true
+}
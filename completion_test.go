@@ -0,0 +1,183 @@
+package hyprls
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	parser_data "github.com/ewen-lbh/hyprls/parser/data"
+	"go.lsp.dev/protocol"
+	"go.uber.org/zap"
+)
+
+func TestNoneValueCompletions(t *testing.T) {
+	cursorTheme := &parser_data.VariableDefinition{Name: "theme", Type: "str", Description: "the cursor theme to use, or none to keep the default one"}
+	if items := noneValueCompletions(cursorTheme); len(items) != 1 || items[0].Label != "none" {
+		t.Errorf("expected none to be proposed, got %#v", items)
+	}
+
+	tabletOutput := &parser_data.VariableDefinition{Name: "output", Type: "string", Description: "the monitor to bind tablets. Empty means unbound."}
+	if items := noneValueCompletions(tabletOutput); len(items) != 0 {
+		t.Errorf("expected none not to be proposed when the description only mentions empty values, got %#v", items)
+	}
+
+	accelProfile := &parser_data.VariableDefinition{Name: "accel_profile", Type: "str", Description: "Can be one of adaptive, flat or custom, none uses libinput's default", EnumValues: []string{"adaptive", "flat", "custom"}}
+	if items := noneValueCompletions(accelProfile); len(items) != 0 {
+		t.Errorf("expected none not to be proposed for variables with enumerated values, got %#v", items)
+	}
+
+	kbLayout := &parser_data.VariableDefinition{Name: "kb_layout", Type: "str", Description: "Appropriate XKB keymap parameter"}
+	if items := noneValueCompletions(kbLayout); len(items) != 0 {
+		t.Errorf("expected none not to be proposed when the description does not mention it, got %#v", items)
+	}
+
+	rounding := &parser_data.VariableDefinition{Name: "rounding", Type: "int", Description: "none means no rounding"}
+	if items := noneValueCompletions(rounding); len(items) != 0 {
+		t.Errorf("expected none not to be proposed for non-string variables, got %#v", items)
+	}
+}
+
+func TestCompletionInValuePosition(t *testing.T) {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	uri := protocol.URI("file:///tmp/hyprls-value-completion.conf")
+	openedFiles[uri] = "general:layout = \n"
+	defer delete(openedFiles, uri)
+
+	list, err := Handler{}.Completion(context.Background(), &protocol.CompletionParams{
+		TextDocumentPositionParams: protocol.TextDocumentPositionParams{
+			TextDocument: protocol.TextDocumentIdentifier{URI: uri},
+			Position:     protocol.Position{Line: 0, Character: 17},
+		},
+		Context: &protocol.CompletionContext{TriggerKind: protocol.CompletionTriggerKindInvoked},
+	})
+	if err != nil || list == nil {
+		t.Fatalf("expected completions, got %#v (%v)", list, err)
+	}
+
+	labels := make([]string, 0, len(list.Items))
+	for _, item := range list.Items {
+		if item.Kind == protocol.CompletionItemKindModule || item.Kind == protocol.CompletionItemKindKeyword {
+			t.Errorf("expected no section or keyword completions after the =, got %q", item.Label)
+		}
+		labels = append(labels, item.Label)
+	}
+	if !slices.Contains(labels, "dwindle") || !slices.Contains(labels, "master") {
+		t.Errorf("expected the layouts to be proposed, got %v", labels)
+	}
+}
+
+func TestCursorIsInValue(t *testing.T) {
+	for _, c := range []struct {
+		line      string
+		character uint32
+		expected  bool
+	}{
+		{"general:layout = ", 17, true},
+		{"general:layout = ", 3, false},
+		{"decoration {", 12, false},
+		{"    ", 4, false},
+		{"$é = ", 4, true},
+	} {
+		if actual := cursorIsInValue(c.line, protocol.Position{Character: c.character}); actual != c.expected {
+			t.Errorf("cursorIsInValue(%q, %d) = %v, expected %v", c.line, c.character, actual, c.expected)
+		}
+	}
+}
+
+func TestExecOnceCompletions(t *testing.T) {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	uri := protocol.URI("file:///tmp/hyprls-exec-once-completion.conf")
+	openedFiles[uri] = "exec\ndecoration {\n    \n}\n"
+	defer delete(openedFiles, uri)
+
+	complete := func(position protocol.Position) map[string]protocol.CompletionItem {
+		list, err := Handler{}.Completion(context.Background(), &protocol.CompletionParams{
+			TextDocumentPositionParams: protocol.TextDocumentPositionParams{
+				TextDocument: protocol.TextDocumentIdentifier{URI: uri},
+				Position:     position,
+			},
+		})
+		if err != nil || list == nil {
+			t.Fatalf("expected completions, got %#v (%v)", list, err)
+		}
+		items := make(map[string]protocol.CompletionItem)
+		for _, item := range list.Items {
+			items[item.Label] = item
+		}
+		return items
+	}
+
+	items := complete(protocol.Position{Line: 0, Character: 4})
+	if keyword := items["exec-once"]; keyword.InsertText != "exec-once = $0" || keyword.InsertTextFormat != protocol.InsertTextFormatSnippet {
+		t.Errorf("expected exec-once to expand to a snippet, got %#v", keyword)
+	}
+	if waybar, ok := items["exec-once = waybar"]; !ok || waybar.Kind != protocol.CompletionItemKindSnippet {
+		t.Errorf("expected a snippet to start waybar, got %#v", waybar)
+	}
+
+	if _, ok := complete(protocol.Position{Line: 2, Character: 4})["exec-once = waybar"]; ok {
+		t.Error("expected no autostart snippets inside a section")
+	}
+}
+
+func TestKeywordSnippetCompletion(t *testing.T) {
+	bind, _ := parser_data.FindKeyword("bind")
+	item := keywordSnippetCompletion(bind)
+	if item.InsertText != "bind = ${1:mods}, ${2:key}, ${3:dispatcher}, ${4:args}" || item.InsertTextFormat != protocol.InsertTextFormatSnippet {
+		t.Errorf("expected a tab stop for each parameter of bind, got %#v", item)
+	}
+	if item.Label != "bind = mods, key, dispatcher, args" {
+		t.Errorf("expected the label to show the parameters, got %q", item.Label)
+	}
+}
+
+func TestArgumentIndex(t *testing.T) {
+	for _, c := range []struct {
+		line      string
+		character uint32
+		index     int
+		ok        bool
+	}{
+		{"bind = SUPER, Q, exec, kitty", 10, 0, true},
+		{"bind = SUPER, Q, exec, kitty", 15, 1, true},
+		{"bind = SUPER, Q, exec", 21, 2, true},
+		{"bind = SUPER, Q, exec, notify-send a\\, b", 40, 3, true},
+		{"monitor = é, preferred, ", 24, 2, true},
+		{"bind = SUPER, Q, exec # a, b", 21, 2, true},
+		{"bind = SUPER # a, b, c, ", 24, 0, false},
+		{"bind = SUPER, \\,, workspace, ", 29, 3, true},
+		{"bind", 4, 0, false},
+	} {
+		index, ok := argumentIndex(c.line, protocol.Position{Character: c.character})
+		if index != c.index || ok != c.ok {
+			t.Errorf("argumentIndex(%q, %d) = %d, %v, expected %d, %v", c.line, c.character, index, ok, c.index, c.ok)
+		}
+	}
+}
+
+func TestBindWorkspaceCompletions(t *testing.T) {
+	for _, line := range []string{"bind = SUPER, Q, workspace, ", "bind = SUPER, \\,, workspace, "} {
+		items, ok := bindCompletions(line, protocol.Position{Character: uint32(len(line))})
+		if !ok || len(items) != len(parser_data.WorkspaceSelectors) {
+			t.Errorf("expected workspace selectors for %q, got %#v", line, items)
+		}
+	}
+
+	line := "bind = SUPER # a, b, c, "
+	if items, ok := bindCompletions(line, protocol.Position{Character: uint32(len(line))}); ok {
+		t.Errorf("expected no completions in a comment, got %#v", items)
+	}
+}
+
+func TestLineArgumentsCountCharacters(t *testing.T) {
+	arguments := lineArguments("monitor = é, preferred", 0)
+	if len(arguments) != 2 || arguments[1].Range.Start.Character != 13 || arguments[1].Range.End.Character != 22 {
+		t.Errorf("expected columns to be counted in characters, got %#v", arguments)
+	}
+}
@@ -0,0 +1,206 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	parser_data "github.com/ewen-lbh/hyprls/parser/data"
+)
+
+// DefaultBezier is the curve Hyprland always defines
+const DefaultBezier = "default"
+
+// Bezier is a bezier = NAME, X0, Y0, X1, Y1 line
+type Bezier struct {
+	Name string
+	// Range is the range of the curve's name
+	Range Range
+	// Points are the control points' arguments, X0, Y0, X1, Y1
+	Points []Argument
+}
+
+// Beziers returns the bezier = NAME, X0, Y0, X1, Y1 lines of the file
+func Beziers(contents string) []Bezier {
+	definitions := make([]Bezier, 0)
+	for i, line := range strings.Split(contents, "\n") {
+		if LineKey(line) != "bezier" {
+			continue
+		}
+
+		arguments := LineArguments(line, i)
+		if len(arguments) == 0 || arguments[0].Value == "" {
+			continue
+		}
+		definitions = append(definitions, Bezier{
+			Name:   arguments[0].Value,
+			Range:  arguments[0].Range,
+			Points: arguments[1:],
+		})
+	}
+	return definitions
+}
+
+// beziers checks the control points of bezier curves, and reports animations using curves defined neither in the file nor in its related files
+func (c Config) beziers() []Diagnostic {
+	variables := c.Variables()
+	diagnostics := make([]Diagnostic, 0)
+	for _, definition := range Beziers(c.source) {
+		if len(definition.Points) != 4 {
+			diagnostics = append(diagnostics, Diagnostic{
+				Range:    definition.Range,
+				Severity: SeverityError,
+				Message:  fmt.Sprintf("Bezier curve %s needs 4 control points (X0, Y0, X1, Y1), got %d", definition.Name, len(definition.Points)),
+				Code:     CodeInvalidBezier,
+			})
+			continue
+		}
+
+		for _, point := range definition.Points {
+			value, known := expandVariables(point.Value, variables)
+			if !known {
+				continue
+			}
+			if _, err := strconv.ParseFloat(value, 64); err != nil {
+				diagnostics = append(diagnostics, Diagnostic{
+					Range:    point.Range,
+					Severity: SeverityError,
+					Message:  fmt.Sprintf("Control point %q is not a number", point.Value),
+					Code:     CodeInvalidBezier,
+				})
+			}
+		}
+	}
+
+	defined := map[string]bool{DefaultBezier: true}
+	for _, file := range append([]*Config{&c}, c.Related...) {
+		for _, definition := range Beziers(file.source) {
+			defined[definition.Name] = true
+		}
+	}
+	for i, line := range strings.Split(c.source, "\n") {
+		if LineKey(line) != "animation" {
+			continue
+		}
+
+		// animation = NAME, ONOFF, SPEED, CURVE[, STYLE]
+		arguments := LineArguments(line, i)
+		if len(arguments) < 4 || arguments[3].Value == "" {
+			continue
+		}
+		curve := arguments[3]
+		if name, known := expandVariables(curve.Value, variables); !known || defined[name] {
+			continue
+		}
+		diagnostics = append(diagnostics, Diagnostic{
+			Range:    curve.Range,
+			Severity: SeverityError,
+			Message:  fmt.Sprintf("Bezier curve %s is not defined", curve.Value),
+			Code:     CodeUndefinedBezier,
+		})
+	}
+	return diagnostics
+}
+
+// animations reports unknown animation names, and styles the animation does not support
+func (c Config) animations() []Diagnostic {
+	diagnostics := make([]Diagnostic, 0)
+	for i, line := range strings.Split(c.source, "\n") {
+		if LineKey(line) != "animation" {
+			continue
+		}
+
+		arguments := LineArguments(line, i)
+		if len(arguments) == 0 || arguments[0].Value == "" {
+			continue
+		}
+
+		animation, found := parser_data.FindAnimation(arguments[0].Value)
+		if !found {
+			diagnostics = append(diagnostics, Diagnostic{
+				Range:    arguments[0].Range,
+				Severity: SeverityError,
+				Message:  fmt.Sprintf("Unknown animation %s", arguments[0].Value),
+				Code:     CodeUnknownAnimation,
+			})
+			continue
+		}
+
+		if len(arguments) < 5 {
+			continue
+		}
+
+		// Styles can take a parameter, e.g. popin 80% or slide left
+		style, _, _ := strings.Cut(arguments[4].Value, " ")
+		if style == "" || strings.HasPrefix(style, "$") || slices.Contains(animation.Styles, style) {
+			continue
+		}
+
+		message := fmt.Sprintf("Animation %s does not support styles", animation.Name)
+		if len(animation.Styles) > 0 {
+			message = fmt.Sprintf("Unknown style %s for animation %s, expected one of: %s", style, animation.Name, strings.Join(animation.Styles, ", "))
+		}
+		diagnostics = append(diagnostics, Diagnostic{
+			Range:    arguments[4].Range,
+			Severity: SeverityError,
+			Message:  message,
+			Code:     CodeInvalidAnimationStyle,
+		})
+	}
+	return diagnostics
+}
+
+// animationArguments reports ONOFF arguments of animation = NAME, ONOFF, SPEED, ... lines that are not 0 or 1,
+// and SPEED arguments that are not positive numbers
+func (c Config) animationArguments() []Diagnostic {
+	variables := c.Variables()
+	diagnostics := make([]Diagnostic, 0)
+	for i, line := range strings.Split(c.source, "\n") {
+		if LineKey(line) != "animation" {
+			continue
+		}
+
+		arguments := LineArguments(line, i)
+		if len(arguments) < 2 {
+			continue
+		}
+
+		if enabled, known := expandVariables(arguments[1].Value, variables); known && enabled != "" && enabled != "0" && enabled != "1" {
+			diagnostics = append(diagnostics, Diagnostic{
+				Range:    arguments[1].Range,
+				Severity: SeverityError,
+				Message:  fmt.Sprintf("ONOFF must be 0 or 1, got %q", arguments[1].Value),
+				Code:     CodeInvalidAnimationArgument,
+			})
+		}
+
+		if len(arguments) < 3 {
+			continue
+		}
+
+		speed := arguments[2]
+		value, known := expandVariables(speed.Value, variables)
+		if !known {
+			continue
+		}
+
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			diagnostics = append(diagnostics, Diagnostic{
+				Range:    speed.Range,
+				Severity: SeverityError,
+				Message:  fmt.Sprintf("Speed %q is not a number", speed.Value),
+				Code:     CodeInvalidAnimationArgument,
+			})
+		} else if parsed <= 0 {
+			diagnostics = append(diagnostics, Diagnostic{
+				Range:    speed.Range,
+				Severity: SeverityWarning,
+				Message:  "Speed must be positive, it is the animation's duration in ds (1ds = 100ms)",
+				Code:     CodeInvalidAnimationArgument,
+			})
+		}
+	}
+	return diagnostics
+}
@@ -0,0 +1,101 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAnimationArguments(t *testing.T) {
+	cases := []struct {
+		name     string
+		contents string
+		// expected are the messages of the diagnostics, in order
+		expected []string
+	}{
+		{"valid", "animation = windows, 1, 7, default\n", []string{}},
+		{"disabled", "animation = windows, 0\n", []string{}},
+		{"fractional speed", "animation = windows, 1, 0.5, default\n", []string{}},
+		{"invalid onoff", "animation = windows, yes, 7, default\n", []string{`ONOFF must be 0 or 1, got "yes"`}},
+		{"speed not a number", "animation = windows, 1, fast, default\n", []string{`Speed "fast" is not a number`}},
+		{"zero speed", "animation = windows, 1, 0, default\n", []string{"Speed must be positive, it is the animation's duration in ds (1ds = 100ms)"}},
+		{"negative speed", "animation = windows, 1, -3, default\n", []string{"Speed must be positive, it is the animation's duration in ds (1ds = 100ms)"}},
+		{"literal variables", "$on = 1\n$speed = 4\nanimation = windows, $on, $speed, default\n", []string{}},
+		{"invalid literal variables", "$on = 2\n$speed = 0\nanimation = windows, $on, $speed, default\n", []string{
+			`ONOFF must be 0 or 1, got "$on"`,
+			"Speed must be positive, it is the animation's duration in ds (1ds = 100ms)",
+		}},
+		{"unknown variables", "animation = windows, $on, $speed, default\n", []string{}},
+		{"variables defined with other variables", "$base = 3\n$speed = $base\nanimation = windows, 1, $speed, default\n", []string{}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			messages := make([]string, 0)
+			for _, diagnostic := range mustParse(t, c.contents).animationArguments() {
+				messages = append(messages, diagnostic.Message)
+			}
+			if !reflect.DeepEqual(messages, c.expected) {
+				t.Errorf("expected %q, got %q", c.expected, messages)
+			}
+		})
+	}
+}
+
+func TestAnimations(t *testing.T) {
+	cases := []struct {
+		name     string
+		contents string
+		expected []string
+	}{
+		{"known animation", "animation = windows, 1, 7, default, popin 80%\n", []string{}},
+		{"unknown animation", "animation = windowz, 1, 7, default\n", []string{"Unknown animation windowz"}},
+		{"unknown style", "animation = windows, 1, 7, default, explode\n", []string{"Unknown style explode for animation windows, expected one of: slide, popin"}},
+		{"style variable", "animation = windows, 1, 7, default, $style\n", []string{}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			messages := make([]string, 0)
+			for _, diagnostic := range mustParse(t, c.contents).animations() {
+				messages = append(messages, diagnostic.Message)
+			}
+			if !reflect.DeepEqual(messages, c.expected) {
+				t.Errorf("expected %q, got %q", c.expected, messages)
+			}
+		})
+	}
+}
+
+func TestBeziers(t *testing.T) {
+	cases := []struct {
+		name     string
+		contents string
+		related  string
+		expected []string
+	}{
+		{"defined curve", "bezier = snappy, 0.05, 0.9, 0.1, 1.05\nanimation = windows, 1, 7, snappy\n", "", []string{}},
+		{"default curve", "animation = windows, 1, 7, default\n", "", []string{}},
+		{"curve defined in a related file", "animation = windows, 1, 7, snappy\n", "bezier = snappy, 0.05, 0.9, 0.1, 1.05\n", []string{}},
+		{"undefined curve", "animation = windows, 1, 7, snappy\n", "", []string{"Bezier curve snappy is not defined"}},
+		{"curve variable", "$curve = snappy\nanimation = windows, 1, 7, $curve\n", "", []string{"Bezier curve $curve is not defined"}},
+		{"missing control points", "bezier = snappy, 0.05, 0.9\n", "", []string{"Bezier curve snappy needs 4 control points (X0, Y0, X1, Y1), got 2"}},
+		{"control point not a number", "bezier = snappy, 0.05, 0.9, high, 1.05\n", "", []string{`Control point "high" is not a number`}},
+		{"control point variables", "$x = 0.1\nbezier = snappy, $x, 0.9, $y, 1.05\n", "", []string{}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			config := mustParse(t, c.contents)
+			if c.related != "" {
+				config.Related = []*Config{mustParse(t, c.related)}
+			}
+			messages := make([]string, 0)
+			for _, diagnostic := range config.beziers() {
+				messages = append(messages, diagnostic.Message)
+			}
+			if !reflect.DeepEqual(messages, c.expected) {
+				t.Errorf("expected %q, got %q", c.expected, messages)
+			}
+		})
+	}
+}
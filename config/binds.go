@@ -0,0 +1,128 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/ewen-lbh/hyprls/parser"
+	parser_data "github.com/ewen-lbh/hyprls/parser/data"
+)
+
+// Bind is a bind = MODS, KEY, ... line
+type Bind struct {
+	// Submap is the submap the bind is defined in, empty for the root
+	Submap string
+	// Flags are the bind's flags that change when it is triggered, e.g. r for bindr
+	Flags string
+	// Mods are the bind's normalized modifiers, sorted
+	Mods []string
+	Key  string
+	// Arguments are all of the bind's arguments, including the dispatcher and its params
+	Arguments []Argument
+	// Range spans the bind's modifiers and key
+	Range Range
+}
+
+// Combination returns the bind's modifiers and key, e.g. SUPER+Return
+func (b Bind) Combination() string {
+	return strings.Join(append(slices.Clone(b.Mods), b.Key), "+")
+}
+
+// conflictsWith is true if both binds are triggered by the same keys
+func (b Bind) conflictsWith(other Bind) bool {
+	return b.Submap == other.Submap && b.Flags == other.Flags && slices.Equal(b.Mods, other.Mods) && strings.EqualFold(b.Key, other.Key)
+}
+
+// bindTriggerFlags are the bind flags that change what triggers the bind: binds that differ by those don't conflict
+var bindTriggerFlags = "rm"
+
+// Binds returns the bind = MODS, KEY, ... lines of a file, along with the submap they are in
+func Binds(contents string) []Bind {
+	binds := make([]Bind, 0)
+	submap := ""
+	for i, line := range strings.Split(contents, "\n") {
+		key := LineKey(line)
+		arguments := LineArguments(line, i)
+		if key == "submap" && len(arguments) > 0 {
+			submap = arguments[0].Value
+			if submap == "reset" {
+				submap = ""
+			}
+			continue
+		}
+
+		if keyword, found := parser_data.FindKeyword(key); !found || keyword.Name != "bind" || len(arguments) < 2 {
+			continue
+		}
+
+		flags := ""
+		for _, flag := range strings.TrimPrefix(key, "bind") {
+			if strings.ContainsRune(bindTriggerFlags, flag) && !strings.ContainsRune(flags, flag) {
+				flags += string(flag)
+			}
+		}
+
+		binds = append(binds, Bind{
+			Submap:    submap,
+			Flags:     flags,
+			Mods:      normalizedMods(arguments[0].Value),
+			Key:       arguments[1].Value,
+			Arguments: arguments,
+			Range: Range{
+				Start: arguments[0].Range.Start,
+				End:   arguments[1].Range.End,
+			},
+		})
+	}
+	return binds
+}
+
+// normalizedMods returns the modifiers of a MODS field, without duplicates, sorted, and using a single name for each modifier (e.g. CTRL for CONTROL)
+func normalizedMods(raw string) []string {
+	canonicalNames := map[parser.ModKey]string{
+		parser.ModSuper:   "SUPER",
+		parser.ModControl: "CTRL",
+	}
+
+	mods := make([]string, 0)
+	for _, mod := range strings.FieldsFunc(raw, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '$' }) {
+		if key, ok := parser.ModKeyNames[strings.ToUpper(mod)]; ok {
+			mod = strings.ToUpper(mod)
+			if canonical, ok := canonicalNames[key]; ok {
+				mod = canonical
+			}
+		}
+		if !slices.Contains(mods, mod) {
+			mods = append(mods, mod)
+		}
+	}
+	slices.Sort(mods)
+	return mods
+}
+
+// bindConflicts warns about binds that are overridden by a later bind on the same keys, in the same submap
+func (c Config) bindConflicts() []Diagnostic {
+	binds := Binds(c.source)
+	diagnostics := make([]Diagnostic, 0)
+	for i, bind := range binds {
+		for _, overriding := range binds[i+1:] {
+			if !bind.conflictsWith(overriding) {
+				continue
+			}
+
+			diagnostics = append(diagnostics, Diagnostic{
+				Range:    bind.Range,
+				Severity: SeverityWarning,
+				Message:  fmt.Sprintf("%s is bound again later, this bind is never triggered", bind.Combination()),
+				Code:     CodeBindConflict,
+				Related: []RelatedInformation{
+					{Range: overriding.Range, Message: "Bound again here"},
+				},
+			})
+			break
+		}
+	}
+	return diagnostics
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBindConflicts(t *testing.T) {
+	cases := []struct {
+		name     string
+		contents string
+		// expected are the lines of the binds reported as overridden
+		expected []int
+	}{
+		{"same keys", "bind = SUPER, Q, exec, kitty\nbind = SUPER, Q, killactive\n", []int{0}},
+		{"different keys", "bind = SUPER, Q, exec, kitty\nbind = SUPER, W, killactive\n", []int{}},
+		{"key case", "bind = SUPER, q, exec, kitty\nbind = SUPER, Q, killactive\n", []int{0}},
+		{"reported once", "bind = SUPER, Q, exec, kitty\nbind = SUPER, Q, killactive\nbind = SUPER, Q, exit\n", []int{0, 1}},
+		{"control aliases", "bind = CTRL, C, exec, kitty\nbind = CONTROL, C, killactive\n", []int{0}},
+		{"modifiers order and case", "bind = SUPER SHIFT, Q, exec, kitty\nbind = shift_super, Q, killactive\n", []int{0}},
+		{"duplicate modifiers", "bind = SUPER SUPER, Q, exec, kitty\nbind = SUPER, Q, killactive\n", []int{0}},
+		{"different modifiers", "bind = SUPER, Q, exec, kitty\nbind = SUPER SHIFT, Q, killactive\n", []int{}},
+		{"release flag", "bind = SUPER, Q, exec, kitty\nbindr = SUPER, Q, killactive\n", []int{}},
+		{"mouse flag", "bind = SUPER, mouse:272, exec, kitty\nbindm = SUPER, mouse:272, movewindow\n", []int{}},
+		{"flags that don't change the trigger", "bind = SUPER, Q, exec, kitty\nbindel = SUPER, Q, killactive\n", []int{0}},
+		{"same flags in another order", "bindrl = SUPER, Q, exec, kitty\nbindlr = SUPER, Q, killactive\n", []int{0}},
+		{"submaps", "bind = SUPER, R, submap, resize\nsubmap = resize\nbind = , R, exec, kitty\nsubmap = reset\nbind = , R, killactive\n", []int{}},
+		{"same submap", "submap = resize\nbind = , escape, submap, reset\nbind = , escape, exec, kitty\nsubmap = reset\n", []int{1}},
+		{"back to the root", "bind = SUPER, Q, exec, kitty\nsubmap = resize\nsubmap = reset\nbind = SUPER, Q, killactive\n", []int{0}},
+		{"variables", "bind = $mainMod, Q, exec, kitty\nbind = SUPER, Q, killactive\nbind = $mainMod, $key, exit\n", []int{}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			lines := make([]int, 0)
+			for _, diagnostic := range (&Config{source: c.contents}).bindConflicts() {
+				lines = append(lines, diagnostic.Range.Start.Line)
+			}
+			if !reflect.DeepEqual(lines, c.expected) {
+				t.Errorf("expected conflicts on lines %v, got %v", c.expected, lines)
+			}
+		})
+	}
+}
+
+func TestBindConflictsRelatedInformation(t *testing.T) {
+	diagnostics := (&Config{source: "bind = CTRL, C, exec, kitty\nbind = CONTROL, C, killactive\n"}).bindConflicts()
+	if len(diagnostics) != 1 {
+		t.Fatalf("expected a conflict, got %#v", diagnostics)
+	}
+	if diagnostics[0].Message != "CTRL+C is bound again later, this bind is never triggered" {
+		t.Errorf("expected the normalized combination in the message, got %q", diagnostics[0].Message)
+	}
+	if len(diagnostics[0].Related) != 1 || diagnostics[0].Related[0].Range.Start.Line != 1 {
+		t.Errorf("expected the overriding bind to be related, got %#v", diagnostics[0].Related)
+	}
+}
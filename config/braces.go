@@ -0,0 +1,67 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ewen-lbh/hyprls/parser"
+)
+
+type openingBrace struct {
+	Position parser.Position
+	// Section is what comes before the brace on its line, e.g. decoration for decoration {
+	Section string
+}
+
+// unbalancedBraces reports the { that are never closed and the } that close nothing.
+// Braces in comments and in quoted strings, e.g. in exec = awk '{print $1}', don't count.
+func (c Config) unbalancedBraces() []Diagnostic {
+	diagnostics := make([]Diagnostic, 0)
+	opened := make([]openingBrace, 0)
+	for i, line := range strings.Split(c.source, "\n") {
+		runes := []rune(line)
+		var quote rune
+	scan:
+		for j := 0; j < len(runes); j++ {
+			position := parser.Position{Line: i, Column: j}
+			switch {
+			// ## is an escaped #, not the start of a comment
+			case runes[j] == '#' && j+1 < len(runes) && runes[j+1] == '#':
+				j++
+			case runes[j] == '#':
+				break scan
+			case quote != 0:
+				if runes[j] == quote {
+					quote = 0
+				}
+			case runes[j] == '"' || runes[j] == '\'':
+				quote = runes[j]
+			case runes[j] == '{':
+				opened = append(opened, openingBrace{Position: position, Section: strings.TrimSpace(string(runes[:j]))})
+			case runes[j] == '}' && len(opened) > 0:
+				opened = opened[:len(opened)-1]
+			case runes[j] == '}':
+				diagnostics = append(diagnostics, Diagnostic{
+					Range:    Range{Start: position, End: parser.Position{Line: i, Column: j + 1}},
+					Severity: SeverityError,
+					Message:  "This } does not close any section",
+					Code:     CodeUnbalancedBraces,
+				})
+			}
+		}
+	}
+
+	for _, brace := range opened {
+		message := fmt.Sprintf("The section opened on line %d is never closed", brace.Position.Line+1)
+		if brace.Section != "" {
+			message = fmt.Sprintf("The %s section opened on line %d is never closed", brace.Section, brace.Position.Line+1)
+		}
+		diagnostics = append(diagnostics, Diagnostic{
+			Range:    Range{Start: brace.Position, End: parser.Position{Line: brace.Position.Line, Column: brace.Position.Column + 1}},
+			Severity: SeverityError,
+			Message:  message,
+			Code:     CodeUnbalancedBraces,
+		})
+	}
+	return diagnostics
+}
@@ -0,0 +1,28 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/ewen-lbh/hyprls/parser"
+)
+
+func TestUnbalancedBraces(t *testing.T) {
+	config := &Config{source: "general {\n    gaps_in = 5 # {\n}\n}\nexec-once = awk '{print $1' file\ndecoration {\n    blur {\n    }\n"}
+	diagnostics := config.unbalancedBraces()
+	if len(diagnostics) != 2 {
+		t.Fatalf("expected a stray } and an unclosed {, got %#v", diagnostics)
+	}
+
+	if diagnostics[0].Range.Start != (parser.Position{Line: 3, Column: 0}) {
+		t.Errorf("expected the stray } to be reported, got %#v", diagnostics[0])
+	}
+
+	if diagnostics[1].Range.Start != (parser.Position{Line: 5, Column: 11}) || diagnostics[1].Message != "The decoration section opened on line 6 is never closed" {
+		t.Errorf("expected the unclosed decoration section to be reported, got %#v", diagnostics[1])
+	}
+
+	config = &Config{source: "general {\n    col.active_border = rgb(ffffff) ## }\n}\n"}
+	if diagnostics := config.unbalancedBraces(); len(diagnostics) != 1 {
+		t.Errorf("expected the } after an escaped # to count, got %#v", diagnostics)
+	}
+}
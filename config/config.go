@@ -0,0 +1,158 @@
+// Package config parses Hyprland configuration files for use outside of the language server,
+// e.g. to read or validate a configuration from a script.
+package config
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ewen-lbh/hyprls/parser"
+)
+
+// Config is a parsed Hyprland configuration file
+type Config struct {
+	// Document is the root of the configuration, as parsed by parser.Parse
+	Document parser.Section
+	// Path is the file the configuration was read from, relative source paths are resolved from its directory.
+	// Empty if the configuration was not read from a file, in which case they are resolved from the current directory.
+	Path string
+	// Related are the other files of the configuration, where custom variables and bezier curves used in this one can be defined, e.g. the files it sources
+	Related []*Config
+	// HyprlandVersion is the installed version of Hyprland, e.g. 0.40.0. Validate reports the variables added in later versions if it is set.
+	HyprlandVersion string
+	source          string
+}
+
+// SectionInstance is a section of the configuration, with the assignments of all the blocks of that section,
+// e.g. both general { ... } blocks if the section appears twice, along with the general:key = value assignments.
+type SectionInstance struct {
+	// Path is the path of the section, e.g. [decoration blur]
+	Path        []string
+	Assignments []parser.Assignment
+}
+
+// ParseConfig reads and parses a configuration
+func ParseConfig(r io.Reader) (*Config, error) {
+	contents, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("while reading: %w", err)
+	}
+
+	source := strings.TrimPrefix(string(contents), parser.ByteOrderMark)
+	document, err := parser.Parse(source)
+	if err != nil {
+		return nil, fmt.Errorf("while parsing: %w", err)
+	}
+
+	return &Config{Document: document, source: source}, nil
+}
+
+// ReadConfig reads and parses the configuration file at path, along with the files it sources, which become its Related files
+func ReadConfig(path string) (*Config, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	config, err := ParseConfig(file)
+	if err != nil {
+		return nil, err
+	}
+	config.Path = path
+
+	for _, sourced := range SourcedFiles(path, readFile) {
+		contents, err := readFile(sourced)
+		if err != nil {
+			continue
+		}
+		related, err := ParseConfig(strings.NewReader(contents))
+		if err != nil {
+			continue
+		}
+		related.Path = sourced
+		config.Related = append(config.Related, related)
+	}
+	return config, nil
+}
+
+func readFile(path string) (string, error) {
+	contents, err := os.ReadFile(path)
+	return string(contents), err
+}
+
+// Variables returns the values of the custom variables defined in the configuration, by name without the $.
+// The last definition wins, like in Hyprland.
+func (c Config) Variables() map[string]string {
+	variables := make(map[string]string)
+	var walk func(section parser.Section)
+	walk = func(section parser.Section) {
+		for _, variable := range section.Variables {
+			variables[variable.Key] = strings.TrimSpace(variable.ValueRaw)
+		}
+		for _, subsection := range section.Subsections {
+			walk(subsection)
+		}
+	}
+	walk(c.Document)
+	return variables
+}
+
+// Section returns the section at the given path, e.g. Section("decoration", "blur"), or nil if the configuration doesn't set anything in it.
+// Names are case-insensitive.
+func (c Config) Section(path ...string) *SectionInstance {
+	if len(path) == 0 {
+		return nil
+	}
+
+	instance := SectionInstance{Path: path}
+	var walk func(section parser.Section, depth int)
+	walk = func(section parser.Section, depth int) {
+		for _, subsection := range section.Subsections {
+			if !strings.EqualFold(subsection.Name, path[depth]) {
+				continue
+			}
+			if depth == len(path)-1 {
+				instance.Assignments = append(instance.Assignments, subsection.Assignments...)
+			} else {
+				walk(subsection, depth+1)
+			}
+		}
+	}
+	walk(c.Document, 0)
+
+	prefix := strings.ToLower(strings.Join(path, ":")) + ":"
+	for _, assignment := range c.Document.Assignments {
+		if key, ok := strings.CutPrefix(strings.ToLower(assignment.Key), prefix); ok && !strings.Contains(key, ":") {
+			assignment.Key = key
+			instance.Assignments = append(instance.Assignments, assignment)
+		}
+	}
+
+	if len(instance.Assignments) == 0 {
+		return nil
+	}
+	return &instance
+}
+
+// Value returns the raw value of the last assignment to the given variable of the section
+func (s SectionInstance) Value(variable string) (value string, found bool) {
+	for _, assignment := range s.Assignments {
+		if assignment.Key == variable {
+			value, found = strings.TrimSpace(assignment.ValueRaw), true
+		}
+	}
+	return value, found
+}
+
+// Format returns the configuration formatted the same way as the language server's formatting, see parser.Format.
+// Configurations with unbalanced braces are not formatted and return an error instead.
+func (c Config) Format() ([]byte, error) {
+	formatted, err := parser.Format(c.source)
+	if err != nil {
+		return nil, fmt.Errorf("while formatting: %w", err)
+	}
+	return []byte(formatted), nil
+}
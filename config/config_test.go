@@ -0,0 +1,283 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ewen-lbh/hyprls/parser"
+	parser_data "github.com/ewen-lbh/hyprls/parser/data"
+)
+
+func mustParse(t *testing.T, contents string) *Config {
+	t.Helper()
+	config, err := ParseConfig(strings.NewReader(contents))
+	if err != nil {
+		t.Fatalf("while parsing: %s", err)
+	}
+	return config
+}
+
+func TestSection(t *testing.T) {
+	config := mustParse(t, parser.ByteOrderMark+`$gaps = 5
+general {
+    gaps_in = $gaps
+}
+decoration {
+    blur {
+        size = 8
+    }
+}
+general {
+    gaps_out = 10
+}
+general:border_size = 2
+decoration:blur:passes = 3
+`)
+
+	if variables := config.Variables(); variables["gaps"] != "5" {
+		t.Errorf("expected $gaps to be 5, got %#v", variables)
+	}
+
+	general := config.Section("General")
+	if general == nil || len(general.Assignments) != 3 {
+		t.Fatalf("expected the assignments of both general blocks and general:border_size, got %#v", general)
+	}
+	if value, _ := general.Value("border_size"); value != "2" {
+		t.Errorf("expected border_size to be 2, got %q", value)
+	}
+
+	blur := config.Section("decoration", "blur")
+	if blur == nil {
+		t.Fatal("expected a decoration:blur section")
+	}
+	if value, _ := blur.Value("passes"); value != "3" {
+		t.Errorf("expected passes to be 3, got %q", value)
+	}
+	if _, found := blur.Value("enabled"); found {
+		t.Error("expected enabled not to be set")
+	}
+
+	if section := config.Section("input"); section != nil {
+		t.Errorf("expected no input section, got %#v", section)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	config := mustParse(t, `$opacity = 2
+autogenerated = 0
+general {
+    layout = spiral
+    col.group_border = rgb(ffffff)
+    nope = 1
+}
+decoration {
+    active_opacity = $opacity
+    inactive_opacity = $undefined
+}
+nonexistent {
+    foo = bar
+}
+plugin {
+    hyprexpo {
+        columns = 3
+    }
+}
+device {
+    name = epic-mouse-v1
+    sensitivity = -0.5
+}
+decoration:blur:nope = 1
+gaps_in = 5
+`)
+
+	expected := []struct {
+		code string
+		line int
+	}{
+		{CodeUndefinedVariable, 9},
+		{CodeInvalidEnumValue, 3},
+		{CodeDeprecated, 4},
+		{CodeUnknownVariable, 5},
+		{CodeOutOfRange, 8},
+		{CodeUnknownSection, 11},
+		{CodeUnknownVariable, 23},
+		{CodeMisplacedVariable, 24},
+	}
+
+	diagnostics := config.Validate(nil)
+	if len(diagnostics) != len(expected) {
+		t.Fatalf("expected %d diagnostics, got %#v", len(expected), diagnostics)
+	}
+	for i, diagnostic := range diagnostics {
+		if diagnostic.Code != expected[i].code || diagnostic.Range.Start.Line != expected[i].line {
+			t.Errorf("expected diagnostic %d to be %s on line %d, got %#v", i, expected[i].code, expected[i].line, diagnostic)
+		}
+	}
+
+	if diagnostics[5].Range.Start.Column != 0 || diagnostics[5].Range.End.Column != len("nonexistent") {
+		t.Errorf("expected the unknown section's name to be reported, got %#v", diagnostics[5].Range)
+	}
+}
+
+func TestValidateExpandsLiteralVariables(t *testing.T) {
+	diagnostics := mustParse(t, "$opacity = 5\n$other = $opacity\ndecoration {\n  active_opacity = $opacity\n  inactive_opacity = $other\n}\n").Validate(nil)
+	if len(diagnostics) != 1 || diagnostics[0].Code != CodeOutOfRange || diagnostics[0].Range.Start.Line != 3 {
+		t.Fatalf("expected a single diagnostic on the assignment using $opacity, got %v", diagnostics)
+	}
+	if diagnostics[0].Range.Start.Column != 19 || diagnostics[0].Range.End.Column != 27 {
+		t.Errorf("expected the value to be reported, got %#v", diagnostics[0].Range)
+	}
+}
+
+func TestExpandVariables(t *testing.T) {
+	variables := mustParse(t, "$gap = 5\n$alias = $gap\n$late = 1\n$late = 2\n").Variables()
+
+	if expanded, ok := expandVariables("$gap", variables); !ok || expanded != "5" {
+		t.Errorf("expected $gap to expand to 5, got %q (ok=%v)", expanded, ok)
+	}
+	if expanded, ok := expandVariables("$late", variables); !ok || expanded != "2" {
+		t.Errorf("expected $late to expand to its last definition, got %q (ok=%v)", expanded, ok)
+	}
+	if _, ok := expandVariables("$alias", variables); ok {
+		t.Error("expected $alias not to be expanded, its value is not a literal")
+	}
+	if _, ok := expandVariables("$undefined", variables); ok {
+		t.Error("expected $undefined not to be expanded")
+	}
+}
+
+func TestValidateRootAssignments(t *testing.T) {
+	if diagnostics := mustParse(t, "general:gaps_in = 5\nautogenerated = 0\n").Validate(nil); len(diagnostics) != 0 {
+		t.Errorf("expected no diagnostics, got %#v", diagnostics)
+	}
+}
+
+func TestValidateFixture(t *testing.T) {
+	file, err := os.Open("../parser/fixtures/test.hl")
+	if err != nil {
+		t.Fatalf("while opening fixture: %s", err)
+	}
+	defer file.Close()
+
+	config, err := ParseConfig(file)
+	if err != nil {
+		t.Fatalf("while parsing: %s", err)
+	}
+
+	for _, diagnostic := range config.Validate(nil) {
+		if diagnostic.Severity == SeverityError {
+			t.Errorf("unexpected error on line %d: %s", diagnostic.Range.Start.Line+1, diagnostic.Message)
+		}
+	}
+}
+
+// outline describes the structure of the section, leaving positions out since formatting changes them
+func outline(section parser.Section, path string) []string {
+	lines := make([]string, 0)
+	for _, assignment := range section.Assignments {
+		lines = append(lines, fmt.Sprintf("%s %s = %s", path, assignment.Key, strings.TrimSpace(assignment.ValueRaw)))
+	}
+	for _, variable := range section.Variables {
+		lines = append(lines, fmt.Sprintf("%s $%s = %s", path, variable.Key, strings.TrimSpace(variable.ValueRaw)))
+	}
+	for _, statement := range section.Statements {
+		lines = append(lines, fmt.Sprintf("%s %s (%d arguments)", path, statement.Keyword, len(statement.Arguments)))
+	}
+	for _, subsection := range section.Subsections {
+		lines = append(lines, outline(subsection, path+"/"+subsection.Name)...)
+	}
+	return lines
+}
+
+func TestFormatRoundTrip(t *testing.T) {
+	contents, err := os.ReadFile("../parser/fixtures/test.hl")
+	if err != nil {
+		t.Fatalf("while reading fixture: %s", err)
+	}
+	config := mustParse(t, string(contents))
+
+	formatted, err := config.Format()
+	if err != nil {
+		t.Fatalf("while formatting: %s", err)
+	}
+	reparsed := mustParse(t, string(formatted))
+
+	if !reflect.DeepEqual(outline(config.Document, ""), outline(reparsed.Document, "")) {
+		t.Errorf("expected the formatted configuration to have the same structure, got:\n%s", formatted)
+	}
+
+	if again, err := reparsed.Format(); err != nil || string(again) != string(formatted) {
+		t.Errorf("expected formatting to be idempotent, got %q (%v)", again, err)
+	}
+}
+
+func TestFormatUnbalancedBraces(t *testing.T) {
+	if formatted, err := mustParse(t, "general {\n    gaps_in = 5\n").Format(); err == nil {
+		t.Errorf("expected an error, got %q", formatted)
+	}
+}
+
+func TestValidateEnumValuesWithArguments(t *testing.T) {
+	diagnostics := mustParse(t, "input {\n    accel_profile = custom 200 0.0 0.5\n    scroll_method =\n    touchpad {\n        scroll_factor = 1\n    }\n}\n").Validate(nil)
+	if len(diagnostics) != 0 {
+		t.Errorf("expected no diagnostics, got %#v", diagnostics)
+	}
+
+	diagnostics = mustParse(t, "input {\n    accel_profile = quadratic 200\n}\n").Validate(nil)
+	if len(diagnostics) != 1 || diagnostics[0].Code != CodeInvalidEnumValue {
+		t.Errorf("expected an invalid value, got %#v", diagnostics)
+	}
+}
+
+func TestValidateDeprecationsAndNegativeSizes(t *testing.T) {
+	schema := []parser_data.SectionDefinition{
+		{
+			Path: []string{"General"},
+			Variables: []parser_data.VariableDefinition{
+				{Name: "border_size", Type: "int"},
+				{Name: "gaps", Type: "int"},
+				{Name: "no_cursor_warps", Type: "bool", Deprecated: true},
+			},
+		},
+		{
+			Path:              []string{"Dwindle"},
+			Deprecated:        true,
+			DeprecatedMessage: "use the layout section instead",
+		},
+	}
+
+	diagnostics := mustParse(t, "general {\n    border_size = -2\n    gaps = -2\n    no_cursor_warps = true\n}\ndwindle {\n}\n").Validate(schema)
+	expected := []Diagnostic{
+		{Range: Range{Start: parser.Position{Line: 1, Column: 18}, End: parser.Position{Line: 1, Column: 20}}, Severity: SeverityError, Message: "border_size cannot be negative", Code: CodeNegativeValue},
+		{Range: Range{Start: parser.Position{Line: 3, Column: 4}, End: parser.Position{Line: 3, Column: 19}}, Severity: SeverityWarning, Message: "no_cursor_warps is deprecated and has no effect", Code: CodeDeprecated},
+		{Range: Range{Start: parser.Position{Line: 5, Column: 0}, End: parser.Position{Line: 5, Column: 7}}, Severity: SeverityWarning, Message: "Section dwindle is deprecated, use the layout section instead", Code: CodeDeprecated},
+	}
+	if !reflect.DeepEqual(diagnostics, expected) {
+		t.Errorf("expected %#v, got %#v", expected, diagnostics)
+	}
+}
+
+func TestReadConfigSourcedFiles(t *testing.T) {
+	directory := t.TempDir()
+	if err := os.WriteFile(filepath.Join(directory, "hyprland.conf"), []byte("source = ./variables.conf\ngeneral {\n    gaps_in = $gap\n}\nanimation = windows, 1, 5, snappy\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(directory, "variables.conf"), []byte("$gap = 5\nbezier = snappy, 0.05, 0.9, 0.1, 1.05\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := ReadConfig(filepath.Join(directory, "hyprland.conf"))
+	if err != nil {
+		t.Fatalf("while reading: %s", err)
+	}
+	if len(config.Related) != 1 || config.Related[0].Path != filepath.Join(directory, "variables.conf") {
+		t.Fatalf("expected the sourced file to be related, got %#v", config.Related)
+	}
+	if diagnostics := config.Validate(nil); len(diagnostics) != 0 {
+		t.Errorf("expected the variable and curve defined in the sourced file to be found, got %#v", diagnostics)
+	}
+}
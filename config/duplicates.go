@@ -0,0 +1,42 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/ewen-lbh/hyprls/parser"
+)
+
+// duplicateAssignments warns about assignments that are overridden by a later one in the same section
+func (c Config) duplicateAssignments() []Diagnostic {
+	diagnostics := make([]Diagnostic, 0)
+	var walk func(section parser.Section)
+	walk = func(section parser.Section) {
+		last := make(map[string]parser.Assignment)
+		for _, assignment := range section.Assignments {
+			last[assignment.Key] = assignment
+		}
+
+		for _, assignment := range section.Assignments {
+			overriding := last[assignment.Key]
+			if overriding.Position == assignment.Position {
+				continue
+			}
+
+			diagnostics = append(diagnostics, Diagnostic{
+				Range:    keyRange(assignment),
+				Severity: SeverityWarning,
+				Message:  fmt.Sprintf("%s is set again later in this section, this value is ignored", assignment.Key),
+				Code:     CodeDuplicateAssignment,
+				Related: []RelatedInformation{
+					{Range: keyRange(overriding), Message: "Overridden here"},
+				},
+			})
+		}
+
+		for _, subsection := range section.Subsections {
+			walk(subsection)
+		}
+	}
+	walk(c.Document)
+	return diagnostics
+}
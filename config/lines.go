@@ -0,0 +1,76 @@
+package config
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ewen-lbh/hyprls/parser"
+)
+
+// Argument is one of the comma-separated arguments of a key = arg1, arg2, ... line
+type Argument struct {
+	// Value is the argument, without surrounding whitespace
+	Value string
+	Range Range
+}
+
+// LineArguments returns the comma-separated arguments of a key = arg1, arg2, ... line, comment excluded.
+// lineNumber is the line's index in the file, used for the arguments' ranges.
+func LineArguments(line string, lineNumber int) []Argument {
+	key, value, found := strings.Cut(StripComment(line), "=")
+	if !found {
+		return nil
+	}
+
+	arguments := make([]Argument, 0)
+	offset := len(key) + 1
+	for _, raw := range SplitArguments(value) {
+		trimmed := strings.TrimSpace(raw)
+		start := offset + strings.Index(raw, trimmed)
+		if trimmed == "" {
+			start = offset + len(raw)
+		}
+		arguments = append(arguments, Argument{
+			Value: trimmed,
+			Range: lineRange(line, lineNumber, start, start+len(trimmed)),
+		})
+		offset += len(raw) + 1
+	}
+	return arguments
+}
+
+// SplitArguments splits the value of a key = arg1, arg2, ... line on its commas, except those escaped with a backslash
+func SplitArguments(value string) []string {
+	arguments := make([]string, 0)
+	start := 0
+	for i := 0; i < len(value); i++ {
+		if value[i] == ',' && (i == 0 || value[i-1] != '\\') {
+			arguments = append(arguments, value[start:i])
+			start = i + 1
+		}
+	}
+	return append(arguments, value[start:])
+}
+
+// StripComment removes the comment at the end of the line, if any
+func StripComment(line string) string {
+	before, _, _ := strings.Cut(line, "#")
+	return before
+}
+
+// LineKey returns the key of a key = value line, or an empty string if the line is not an assignment
+func LineKey(line string) string {
+	key, _, found := strings.Cut(StripComment(line), "=")
+	if !found {
+		return ""
+	}
+	return strings.TrimSpace(key)
+}
+
+// lineRange returns the range between two byte offsets of the line at index lineNumber
+func lineRange(line string, lineNumber int, start int, end int) Range {
+	return Range{
+		Start: parser.Position{Line: lineNumber, Column: utf8.RuneCountInString(line[:start])},
+		End:   parser.Position{Line: lineNumber, Column: utf8.RuneCountInString(line[:end])},
+	}
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	parser_data "github.com/ewen-lbh/hyprls/parser/data"
+)
+
+var monitorResolutionPattern = regexp.MustCompile(`^\d+x\d+(@\d+(\.\d+)?)?$`)
+
+var monitorPositionPattern = regexp.MustCompile(`^-?\d+x-?\d+$`)
+
+// monitors checks the structure of monitor = NAME, RESOLUTION, POSITION, SCALE[, EXTRA, VALUE...] lines,
+// and of the monitor = NAME, disable and monitor = NAME, addreserved, TOP, BOTTOM, LEFT, RIGHT forms
+func (c Config) monitors() []Diagnostic {
+	diagnostics := make([]Diagnostic, 0)
+	report := func(at Range, format string, args ...any) {
+		diagnostics = append(diagnostics, Diagnostic{
+			Range:    at,
+			Severity: SeverityError,
+			Message:  fmt.Sprintf(format, args...),
+			Code:     CodeInvalidMonitor,
+		})
+	}
+
+	for i, line := range strings.Split(c.source, "\n") {
+		if LineKey(line) != "monitor" {
+			continue
+		}
+
+		arguments := LineArguments(line, i)
+		whole := Range{Start: arguments[0].Range.Start, End: arguments[len(arguments)-1].Range.End}
+		if len(arguments) < 2 {
+			report(whole, "monitor expects a name, resolution, position and scale")
+			continue
+		}
+
+		switch mode := arguments[1].Value; mode {
+		case "disable":
+			if len(arguments) > 2 {
+				report(Range{Start: arguments[2].Range.Start, End: whole.End}, "A disabled monitor takes no other fields")
+			}
+		case "addreserved":
+			if len(arguments) != 6 {
+				report(whole, "addreserved expects the top, bottom, left and right reserved areas")
+				continue
+			}
+			for _, area := range arguments[2:] {
+				if _, err := strconv.Atoi(area.Value); err != nil && !strings.Contains(area.Value, "$") {
+					report(area.Range, "Reserved areas are in pixels, got %q", area.Value)
+				}
+			}
+		default:
+			if len(arguments) < 4 {
+				report(whole, "monitor expects a name, resolution, position and scale, got %d fields", len(arguments))
+				continue
+			}
+			if len(arguments)%2 != 0 {
+				report(arguments[len(arguments)-1].Range, "Extra arguments go by pairs of a name and a value")
+			}
+
+			resolution, position, scale := arguments[1], arguments[2], arguments[3]
+			if !isMonitorFieldValue(1, resolution.Value, monitorResolutionPattern) && !strings.HasPrefix(resolution.Value, "modeline ") {
+				report(resolution.Range, "Invalid resolution %q, expected WIDTHxHEIGHT[@REFRESHRATE] or one of %s", resolution.Value, strings.Join(parser_data.MonitorFields[1].KeywordValues(), ", "))
+			}
+			if !isMonitorFieldValue(2, position.Value, monitorPositionPattern) {
+				report(position.Range, "Invalid position %q, expected XxY or one of %s", position.Value, strings.Join(parser_data.MonitorFields[2].KeywordValues(), ", "))
+			}
+			if value, err := strconv.ParseFloat(scale.Value, 64); (err != nil || value <= 0) && !isMonitorFieldValue(3, scale.Value, nil) {
+				report(scale.Range, "Invalid scale %q, expected a positive number or auto", scale.Value)
+			}
+		}
+	}
+	return diagnostics
+}
+
+// isMonitorFieldValue returns whether value is one of the keywords of the field at the given index, or matches pattern.
+// Values using custom variables are not checked.
+func isMonitorFieldValue(field int, value string, pattern *regexp.Regexp) bool {
+	if strings.Contains(value, "$") || pattern != nil && pattern.MatchString(value) {
+		return true
+	}
+	return slices.Contains(parser_data.MonitorFields[field].KeywordValues(), value)
+}
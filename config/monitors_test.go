@@ -0,0 +1,47 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMonitors(t *testing.T) {
+	cases := []struct {
+		name     string
+		contents string
+		// expected are the messages of the diagnostics, in order
+		expected []string
+	}{
+		{"valid", "monitor = DP-1, 1920x1080@144, 0x0, 1\n", []string{}},
+		{"keywords", "monitor = , preferred, auto, auto\n", []string{}},
+		{"negative position", "monitor = HDMI-A-1, 2560x1440, -2560x0, 1.5\n", []string{}},
+		{"modeline", "monitor = DP-1, modeline 173 1920 2048 2248 2576 1080 1083 1088 1120 -hsync +vsync, 0x0, 1\n", []string{}},
+		{"extra argument pair", "monitor = DP-1, 1920x1080, 0x0, 1, transform, 1\n", []string{}},
+		{"odd extra argument", "monitor = DP-1, 1920x1080, 0x0, 1, transform\n", []string{"Extra arguments go by pairs of a name and a value"}},
+		{"missing fields", "monitor = DP-1, 1920x1080, 0x0\n", []string{"monitor expects a name, resolution, position and scale, got 3 fields"}},
+		{"name only", "monitor = DP-1\n", []string{"monitor expects a name, resolution, position and scale"}},
+		{"disable", "monitor = DP-1, disable\n", []string{}},
+		{"disable with fields", "monitor = DP-1, disable, 0x0, 1\n", []string{"A disabled monitor takes no other fields"}},
+		{"addreserved", "monitor = DP-1, addreserved, 10, 0, 0, 0\n", []string{}},
+		{"addreserved missing areas", "monitor = DP-1, addreserved, 10, 0\n", []string{"addreserved expects the top, bottom, left and right reserved areas"}},
+		{"addreserved not in pixels", "monitor = DP-1, addreserved, 10px, 0, 0, 0\n", []string{`Reserved areas are in pixels, got "10px"`}},
+		{"invalid fields", "monitor = DP-1, 1920*1080, left, 0\n", []string{
+			`Invalid resolution "1920*1080", expected WIDTHxHEIGHT[@REFRESHRATE] or one of preferred, highres, highrr, modeline, disable, addreserved`,
+			`Invalid position "left", expected XxY or one of auto, auto-right, auto-left, auto-up, auto-down`,
+			`Invalid scale "0", expected a positive number or auto`,
+		}},
+		{"variables", "monitor = $main, $resolution, $position, $scale\nmonitor = DP-2, addreserved, $bar, 0, 0, 0\n", []string{}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			messages := make([]string, 0)
+			for _, diagnostic := range (&Config{source: c.contents}).monitors() {
+				messages = append(messages, diagnostic.Message)
+			}
+			if !reflect.DeepEqual(messages, c.expected) {
+				t.Errorf("expected %q, got %q", c.expected, messages)
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	parser_data "github.com/ewen-lbh/hyprls/parser/data"
+)
+
+// windowRules reports unknown rules in windowrule and windowrulev2 lines, and invalid regular expressions in windowrule lines.
+// Filters of windowrulev2 lines are checked by windowRuleFilters.
+func (c Config) windowRules() []Diagnostic {
+	diagnostics := make([]Diagnostic, 0)
+	for i, line := range strings.Split(c.source, "\n") {
+		key := LineKey(line)
+		if key != "windowrule" && key != "windowrulev2" {
+			continue
+		}
+
+		arguments := LineArguments(line, i)
+		if len(arguments) == 0 {
+			continue
+		}
+		name, nameRange := RuleName(arguments[0])
+		if _, found := parser_data.FindWindowRule(name); !found && isCheckableRuleName(name) {
+			diagnostics = append(diagnostics, Diagnostic{
+				Range:    nameRange,
+				Severity: SeverityWarning,
+				Message:  fmt.Sprintf("Unknown window rule %q", name),
+				Code:     CodeUnknownRule,
+			})
+		}
+
+		// windowrule = RULE, WINDOW matches the window's class, or its title with title:REGEX
+		if key != "windowrule" || len(arguments) < 2 {
+			continue
+		}
+		window := arguments[1]
+		pattern, _ := strings.CutPrefix(window.Value, "title:")
+		if message := invalidRegexp(pattern); message != "" {
+			diagnostics = append(diagnostics, Diagnostic{
+				Range:    window.Range,
+				Severity: SeverityError,
+				Message:  message,
+				Code:     CodeInvalidRegexp,
+			})
+		}
+	}
+	return diagnostics
+}
+
+// layerRules reports unknown rules in layerrule lines. The namespace the rule applies to is free-form.
+func (c Config) layerRules() []Diagnostic {
+	diagnostics := make([]Diagnostic, 0)
+	for i, line := range strings.Split(c.source, "\n") {
+		if LineKey(line) != "layerrule" {
+			continue
+		}
+
+		arguments := LineArguments(line, i)
+		if len(arguments) == 0 {
+			continue
+		}
+		name, nameRange := RuleName(arguments[0])
+		if _, found := parser_data.FindLayerRule(name); !found && isCheckableRuleName(name) {
+			diagnostics = append(diagnostics, Diagnostic{
+				Range:    nameRange,
+				Severity: SeverityWarning,
+				Message:  fmt.Sprintf("Unknown layer rule %q", name),
+				Code:     CodeUnknownRule,
+			})
+		}
+	}
+	return diagnostics
+}
+
+// isCheckableRuleName is false for rule names that can't be looked up in the documentation:
+// rules added by plugins, e.g. plugin:hyprbars:nobar, and names using variables
+func isCheckableRuleName(name string) bool {
+	return name != "" && !strings.Contains(name, "$") && !strings.HasPrefix(name, "plugin:")
+}
+
+// RuleName returns the name of the rule given as argument, without the rule's own arguments, e.g. opacity for opacity 0.8
+func RuleName(rule Argument) (string, Range) {
+	name, _, _ := strings.Cut(rule.Value, " ")
+	nameRange := rule.Range
+	nameRange.End.Column = nameRange.Start.Column + utf8.RuneCountInString(name)
+	return name, nameRange
+}
+
+// invalidRegexp returns why pattern is not a valid regular expression, or an empty string if it is.
+// Hyprland uses RE2, which has the same syntax as Go's regexp package.
+func invalidRegexp(pattern string) string {
+	if customVariableReferencePattern.MatchString(pattern) {
+		return ""
+	}
+	if _, err := regexp.Compile(pattern); err != nil {
+		return fmt.Sprintf("Invalid regular expression: %s", strings.TrimPrefix(err.Error(), "error parsing regexp: "))
+	}
+	return ""
+}
+
+// windowRuleFilters reports unknown filters of windowrulev2 lines, and values their filter doesn't accept
+func (c Config) windowRuleFilters() []Diagnostic {
+	diagnostics := make([]Diagnostic, 0)
+	for i, line := range strings.Split(c.source, "\n") {
+		if LineKey(line) != "windowrulev2" {
+			continue
+		}
+
+		arguments := LineArguments(line, i)
+		if len(arguments) < 2 {
+			continue
+		}
+
+		for _, filter := range arguments[1:] {
+			name, value, _ := strings.Cut(filter.Value, ":")
+			definition, found := parser_data.FindWindowRuleFilter(name)
+			if !found {
+				diagnostics = append(diagnostics, Diagnostic{
+					Range:    filter.Range,
+					Severity: SeverityError,
+					Message:  fmt.Sprintf("Unknown window filter %q", name),
+					Code:     CodeUnknownFilter,
+				})
+				continue
+			}
+
+			if message := invalidWindowRuleFilterValue(definition, value); message != "" {
+				diagnostics = append(diagnostics, Diagnostic{
+					Range:    filter.Range,
+					Severity: SeverityError,
+					Message:  message,
+					Code:     CodeInvalidFilterValue,
+				})
+			}
+		}
+	}
+	return diagnostics
+}
+
+// invalidWindowRuleFilterValue returns why value is not valid for the filter, or an empty string if it is
+func invalidWindowRuleFilterValue(filter parser_data.WindowRuleFilterDefinition, value string) string {
+	value = strings.TrimSpace(value)
+	if filter.Kind == parser_data.WindowRuleFilterRegex {
+		return invalidRegexp(value)
+	}
+	if strings.Contains(value, "$") {
+		return ""
+	}
+
+	switch filter.Kind {
+	case parser_data.WindowRuleFilterBool:
+		if value != "0" && value != "1" {
+			return fmt.Sprintf("%s only accepts 0 or 1, got %q", filter.Name, value)
+		}
+	case parser_data.WindowRuleFilterInteger:
+		if _, err := strconv.Atoi(value); err != nil {
+			return fmt.Sprintf("%s only accepts a number, got %q", filter.Name, value)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,22 @@
+package config
+
+import "testing"
+
+func TestWindowRules(t *testing.T) {
+	config := &Config{source: "windowrulev2 = float, class:kitty\nwindowrulev2 = plugin:hyprbars:nobar, class:kitty\nwindowrulev2 = floating, class:kitty\n"}
+	diagnostics := config.windowRules()
+	if len(diagnostics) != 1 || diagnostics[0].Range.Start.Line != 2 {
+		t.Fatalf("expected only the misspelled rule to be reported, got %#v", diagnostics)
+	}
+	if diagnostics[0].Severity != SeverityWarning {
+		t.Errorf("expected a warning, as the documentation can lag behind Hyprland, got %v", diagnostics[0].Severity)
+	}
+}
+
+func TestLayerRules(t *testing.T) {
+	config := &Config{source: "layerrule = blur, waybar\nlayerrule = plugin:someplugin:rule, waybar\nlayerrule = blurr, waybar\n"}
+	diagnostics := config.layerRules()
+	if len(diagnostics) != 1 || diagnostics[0].Range.Start.Line != 2 || diagnostics[0].Severity != SeverityWarning {
+		t.Errorf("expected a warning about the misspelled rule only, got %#v", diagnostics)
+	}
+}
@@ -0,0 +1,186 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SourceDirective is a source = PATH line
+type SourceDirective struct {
+	// Path is the path as written in the file
+	Path string
+	// Range is the range of the path in the file
+	Range Range
+}
+
+// SourceDirectives returns all the source = ... lines of a file
+func SourceDirectives(contents string) []SourceDirective {
+	directives := make([]SourceDirective, 0)
+	for i, line := range strings.Split(contents, "\n") {
+		key, value, found := strings.Cut(StripComment(line), "=")
+		if !found || strings.TrimSpace(key) != "source" {
+			continue
+		}
+
+		path := strings.TrimSpace(value)
+		if path == "" {
+			continue
+		}
+
+		start := len(key) + 1 + strings.Index(value, path)
+		directives = append(directives, SourceDirective{
+			Path:  path,
+			Range: lineRange(line, i, start, start+len(path)),
+		})
+	}
+	return directives
+}
+
+// ResolveSourcePath resolves a path given to source = ..., relative to the file at from, in which it was written.
+// Like Hyprland, it expands ~ and environment variables.
+func ResolveSourcePath(from string, path string) string {
+	home, _ := os.UserHomeDir()
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		path = home + strings.TrimPrefix(path, "~")
+	}
+
+	path = os.Expand(path, func(name string) string {
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+		if name == "XDG_CONFIG_HOME" {
+			return filepath.Join(home, ".config")
+		}
+		return ""
+	})
+
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(filepath.Dir(from), path)
+	}
+
+	return filepath.Clean(path)
+}
+
+// IsGlobPattern is true if the path given to source = ... is a glob pattern, that can match multiple files
+func IsGlobPattern(path string) bool {
+	return strings.ContainsAny(path, "*?[")
+}
+
+// SourcedPaths returns the paths of the files sourced by a source = PATH line written in the file at from.
+// Glob patterns are expanded, other paths are returned as is, even if they don't exist.
+func SourcedPaths(from string, path string) []string {
+	resolved := ResolveSourcePath(from, path)
+	if !IsGlobPattern(path) {
+		return []string{resolved}
+	}
+
+	matches, err := filepath.Glob(resolved)
+	if err != nil {
+		return []string{}
+	}
+	return matches
+}
+
+// SourcedFiles returns the paths of all files sourced by the file at root, recursively, reading files with read.
+// The root file is not part of the result.
+func SourcedFiles(root string, read func(path string) (string, error)) []string {
+	visited := map[string]bool{root: true}
+	sourced := make([]string, 0)
+
+	var visit func(string)
+	visit = func(current string) {
+		contents, err := read(current)
+		if err != nil {
+			return
+		}
+
+		for _, directive := range SourceDirectives(contents) {
+			for _, path := range SourcedPaths(current, directive.Path) {
+				if visited[path] {
+					continue
+				}
+				visited[path] = true
+				sourced = append(sourced, path)
+				visit(path)
+			}
+		}
+	}
+
+	visit(root)
+	return sourced
+}
+
+// missingSources warns about source = PATH lines whose file doesn't exist, or whose pattern matches no files
+func (c Config) missingSources() []Diagnostic {
+	diagnostics := make([]Diagnostic, 0)
+	for _, directive := range SourceDirectives(c.source) {
+		path := ResolveSourcePath(c.Path, directive.Path)
+		if IsGlobPattern(directive.Path) {
+			if len(SourcedPaths(c.Path, directive.Path)) == 0 {
+				diagnostics = append(diagnostics, Diagnostic{
+					Range:    directive.Range,
+					Severity: SeverityWarning,
+					Message:  fmt.Sprintf("Sourced pattern %s matches no files", path),
+					Code:     CodeMissingSource,
+				})
+			}
+			continue
+		}
+
+		if _, err := os.Stat(path); err == nil {
+			continue
+		}
+
+		diagnostics = append(diagnostics, Diagnostic{
+			Range:    directive.Range,
+			Severity: SeverityWarning,
+			Message:  fmt.Sprintf("Sourced file %s does not exist", path),
+			Code:     CodeMissingSource,
+		})
+	}
+	return diagnostics
+}
+
+// missingExecPaths hints at absolute paths given to exec and exec-once commands that don't exist,
+// e.g. the config file in exec-once = hyprpaper -c ~/.config/hypr/hyprpaper.conf
+func (c Config) missingExecPaths() []Diagnostic {
+	diagnostics := make([]Diagnostic, 0)
+	home, _ := os.UserHomeDir()
+	for i, line := range strings.Split(c.source, "\n") {
+		key, command, found := strings.Cut(StripComment(line), "=")
+		if !found || (strings.TrimSpace(key) != "exec" && strings.TrimSpace(key) != "exec-once") {
+			continue
+		}
+
+		commandStart := len(key) + 1
+		wordEnd := 0
+		for _, word := range strings.Fields(command) {
+			wordStart := wordEnd + strings.Index(command[wordEnd:], word)
+			wordEnd = wordStart + len(word)
+
+			// Options such as --config=PATH
+			_, path, _ := strings.Cut(word, "=")
+			if path == "" {
+				path = word
+			}
+			path = strings.Trim(path, `"'`)
+			if !strings.HasPrefix(path, "/") && !strings.HasPrefix(path, "~/") || strings.ContainsAny(path, "$*?[") {
+				continue
+			}
+
+			if _, err := os.Stat(strings.Replace(path, "~", home, 1)); err == nil {
+				continue
+			}
+
+			diagnostics = append(diagnostics, Diagnostic{
+				Range:    lineRange(line, i, commandStart+wordStart, commandStart+wordEnd),
+				Severity: SeverityHint,
+				Message:  fmt.Sprintf("%s does not exist", path),
+				Code:     CodeMissingPath,
+			})
+		}
+	}
+	return diagnostics
+}
@@ -0,0 +1,363 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ewen-lbh/hyprls/parser"
+	parser_data "github.com/ewen-lbh/hyprls/parser/data"
+)
+
+type Severity int
+
+const (
+	SeverityError Severity = iota + 1
+	SeverityWarning
+	SeverityInformation
+	SeverityHint
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	case SeverityInformation:
+		return "information"
+	case SeverityHint:
+		return "hint"
+	}
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+// MarshalText encodes the severity with its name, e.g. in JSON
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// Range is a range of the configuration, lines and columns start at 0 and columns are counted in characters, see parser.Position
+type Range struct {
+	Start parser.Position `json:"start"`
+	End   parser.Position `json:"end"`
+}
+
+// Diagnostic is a problem found in the configuration by Validate
+type Diagnostic struct {
+	Range    Range    `json:"range"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+	// Code identifies the kind of problem, e.g. unknown-variable
+	Code string `json:"code"`
+	// Related are other places of the same file involved in the problem, e.g. the assignment overriding a duplicate one
+	Related []RelatedInformation `json:"related,omitempty"`
+}
+
+// RelatedInformation is a place of the configuration related to a Diagnostic
+type RelatedInformation struct {
+	Range   Range  `json:"range"`
+	Message string `json:"message"`
+}
+
+// Codes of the diagnostics returned by Validate
+const (
+	CodeUnknownSection    = "unknown-section"
+	CodeUnknownVariable   = "unknown-variable"
+	CodeDeprecated        = "deprecated"
+	CodeInvalidEnumValue  = "invalid-enum-value"
+	CodeOutOfRange        = "out-of-range"
+	CodeMisplacedVariable = "misplaced-variable"
+	CodeNegativeValue     = "negative-value"
+
+	CodeUnbalancedBraces         = "unbalanced-braces"
+	CodeMissingSource            = "missing-source"
+	CodeMissingPath              = "missing-path"
+	CodeUndefinedVariable        = "undefined-variable"
+	CodeUnavailableVariable      = "unavailable-variable"
+	CodeDuplicateAssignment      = "duplicate-assignment"
+	CodeInvalidBezier            = "invalid-bezier"
+	CodeUndefinedBezier          = "undefined-bezier"
+	CodeUnknownAnimation         = "unknown-animation"
+	CodeInvalidAnimationStyle    = "invalid-animation-style"
+	CodeInvalidAnimationArgument = "invalid-animation-argument"
+	CodeBindConflict             = "bind-conflict"
+	CodeUnknownRule              = "unknown-rule"
+	CodeInvalidRegexp            = "invalid-regexp"
+	CodeUnknownFilter            = "unknown-filter"
+	CodeInvalidFilterValue       = "invalid-filter-value"
+	CodeInvalidMonitor           = "invalid-monitor"
+)
+
+var customVariableReferencePattern = regexp.MustCompile(`\$([A-Za-z0-9_]+)`)
+
+// Validate returns the problems of the configuration, checking it against the given sections, or against the ones documented in the wiki (see parser_data.LoadedSections) if schema is nil.
+// Custom variables and bezier curves can also be defined in the Related files, see ReadConfig.
+func (c Config) Validate(schema []parser_data.SectionDefinition) []Diagnostic {
+	if schema == nil {
+		schema = parser_data.LoadedSections()
+	}
+
+	diagnostics := make([]Diagnostic, 0)
+	diagnostics = append(diagnostics, c.unbalancedBraces()...)
+	diagnostics = append(diagnostics, c.missingSources()...)
+	diagnostics = append(diagnostics, c.missingExecPaths()...)
+	diagnostics = append(diagnostics, c.undefinedCustomVariables()...)
+	diagnostics = append(diagnostics, c.unavailableVariables()...)
+	diagnostics = append(diagnostics, c.duplicateAssignments()...)
+	diagnostics = append(diagnostics, c.sections(schema)...)
+	diagnostics = append(diagnostics, c.beziers()...)
+	diagnostics = append(diagnostics, c.animations()...)
+	diagnostics = append(diagnostics, c.animationArguments()...)
+	diagnostics = append(diagnostics, c.bindConflicts()...)
+	diagnostics = append(diagnostics, c.windowRules()...)
+	diagnostics = append(diagnostics, c.windowRuleFilters()...)
+	diagnostics = append(diagnostics, c.layerRules()...)
+	diagnostics = append(diagnostics, c.monitors()...)
+	return diagnostics
+}
+
+// sections checks the sections and variables of the configuration against schema:
+// sections and variables must exist and not be deprecated, values must be one of the values their variable enumerates and be within its range, and sizes can't be negative.
+// Values referencing custom variables are checked with the variables' values.
+func (c Config) sections(schema []parser_data.SectionDefinition) []Diagnostic {
+	definitions := make(map[string]parser_data.SectionDefinition)
+	var index func(sections []parser_data.SectionDefinition)
+	index = func(sections []parser_data.SectionDefinition) {
+		for _, section := range sections {
+			definitions[strings.ToLower(strings.Join(section.Path, ":"))] = section
+			index(section.Subsections)
+		}
+	}
+	index(schema)
+
+	variables := c.Variables()
+	diagnostics := make([]Diagnostic, 0)
+	lines := strings.Split(c.source, "\n")
+
+	var walk func(section parser.Section, path []string)
+	walk = func(section parser.Section, path []string) {
+		for _, subsection := range section.Subsections {
+			subsectionPath := append(slices.Clone(path), strings.ToLower(subsection.Name))
+			if isFreeformSection(subsectionPath) {
+				continue
+			}
+			definition, known := definitions[strings.Join(subsectionPath, ":")]
+			if !known {
+				diagnostics = append(diagnostics, Diagnostic{
+					Range:    sectionNameRange(lines, subsection),
+					Severity: SeverityError,
+					Message:  fmt.Sprintf("Unknown section %s", strings.Join(subsectionPath, ":")),
+					Code:     CodeUnknownSection,
+				})
+				continue
+			}
+			if definition.Deprecated {
+				message := fmt.Sprintf("Section %s is deprecated", subsection.Name)
+				if definition.DeprecatedMessage != "" {
+					message += ", " + definition.DeprecatedMessage
+				}
+				diagnostics = append(diagnostics, Diagnostic{
+					Range:    sectionNameRange(lines, subsection),
+					Severity: SeverityWarning,
+					Message:  message,
+					Code:     CodeDeprecated,
+				})
+			}
+
+			for _, assignment := range subsection.Assignments {
+				diagnostics = append(diagnostics, validateAssignment(assignment, assignment.Key, definition, variables)...)
+			}
+			walk(subsection, subsectionPath)
+		}
+	}
+	walk(c.Document, []string{})
+
+	// At the root, variables are assigned with their full path, e.g. general:gaps_in = 5
+	for _, assignment := range c.Document.Assignments {
+		sectionPath, variable, found := cutLast(strings.ToLower(assignment.Key), ":")
+		if isFreeformSection(strings.Split(sectionPath, ":")) || (!found && parser_data.IsRootVariable(assignment.Key)) {
+			continue
+		}
+		if !found {
+			diagnostics = append(diagnostics, rootAssignmentDiagnostic(assignment, schema))
+			continue
+		}
+
+		definition, known := definitions[sectionPath]
+		if !known {
+			diagnostics = append(diagnostics, Diagnostic{
+				Range:    keyRange(assignment),
+				Severity: SeverityError,
+				Message:  fmt.Sprintf("Unknown section %s", sectionPath),
+				Code:     CodeUnknownSection,
+			})
+			continue
+		}
+		diagnostics = append(diagnostics, validateAssignment(assignment, variable, definition, variables)...)
+	}
+
+	return diagnostics
+}
+
+// validateAssignment checks the assignment to the variable named name of the given section
+func validateAssignment(assignment parser.Assignment, name string, section parser_data.SectionDefinition, variables map[string]string) []Diagnostic {
+	path := strings.ToLower(strings.Join(section.Path, ":"))
+	definition := section.VariableDefinition(name)
+	if definition == nil {
+		return []Diagnostic{{
+			Range:    keyRange(assignment),
+			Severity: SeverityError,
+			Message:  fmt.Sprintf("Unknown variable %s in section %s", name, path),
+			Code:     CodeUnknownVariable,
+		}}
+	}
+
+	diagnostics := make([]Diagnostic, 0)
+	if definition.Deprecated {
+		message := fmt.Sprintf("%s is deprecated and has no effect", name)
+		if definition.ReplacedWith != "" {
+			message = fmt.Sprintf("%s is deprecated, use %s instead", name, definition.ReplacedWith)
+		}
+		diagnostics = append(diagnostics, Diagnostic{
+			Range:    keyRange(assignment),
+			Severity: SeverityWarning,
+			Message:  message,
+			Code:     CodeDeprecated,
+		})
+	}
+
+	value, ok := expandVariables(strings.TrimSpace(assignment.ValueRaw), variables)
+	if !ok {
+		return diagnostics
+	}
+
+	// An empty value stands for the default one, and some values take arguments, e.g. accel_profile = custom 200 0.0 0.5
+	words := strings.Fields(value)
+	if len(definition.EnumValues) > 0 && len(words) > 0 && !slices.Contains(definition.EnumValues, words[0]) {
+		diagnostics = append(diagnostics, Diagnostic{
+			Range:    valueRange(assignment),
+			Severity: SeverityError,
+			Message:  fmt.Sprintf("Invalid value %q for %s, expected one of: %s", words[0], name, strings.Join(definition.EnumValues, ", ")),
+			Code:     CodeInvalidEnumValue,
+		})
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+	isNumeric := definition.Type == "int" || definition.Type == "float"
+	switch {
+	case err != nil || !isNumeric:
+	case definition.Range != nil && !definition.Range.Contains(number):
+		diagnostics = append(diagnostics, Diagnostic{
+			Range:    valueRange(assignment),
+			Severity: SeverityError,
+			Message:  fmt.Sprintf("%s must be between %v and %v", name, definition.Range.Min, definition.Range.Max),
+			Code:     CodeOutOfRange,
+		})
+	case definition.Type == "int" && isGeometryVariable(name) && number < 0:
+		diagnostics = append(diagnostics, Diagnostic{
+			Range:    valueRange(assignment),
+			Severity: SeverityError,
+			Message:  fmt.Sprintf("%s cannot be negative", name),
+			Code:     CodeNegativeValue,
+		})
+	}
+
+	return diagnostics
+}
+
+// rootAssignmentDiagnostic reports an assignment at the root of the configuration without a section path:
+// the variable is either misplaced, if sections define it, or unknown.
+func rootAssignmentDiagnostic(assignment parser.Assignment, schema []parser_data.SectionDefinition) Diagnostic {
+	definedIn := make([]string, 0, 1)
+	for _, section := range schema {
+		if len(section.Path) == 1 && section.VariableDefinition(assignment.Key) != nil {
+			definedIn = append(definedIn, strings.ToLower(section.Path[0]))
+		}
+	}
+
+	diagnostic := Diagnostic{
+		Range:    keyRange(assignment),
+		Severity: SeverityError,
+		Code:     CodeMisplacedVariable,
+	}
+	switch len(definedIn) {
+	case 0:
+		diagnostic.Message = fmt.Sprintf("Unknown variable %s", assignment.Key)
+		diagnostic.Code = CodeUnknownVariable
+	case 1:
+		diagnostic.Message = fmt.Sprintf("%s must be set in the %s section", assignment.Key, definedIn[0])
+	default:
+		diagnostic.Message = fmt.Sprintf("%s must be set in one of the %s sections", assignment.Key, strings.Join(definedIn, ", "))
+	}
+	return diagnostic
+}
+
+// geometryVariableNameParts are parts of variable names that denote sizes, which can't be negative
+var geometryVariableNameParts = []string{"range", "size", "radius", "width"}
+
+func isGeometryVariable(name string) bool {
+	for _, part := range geometryVariableNameParts {
+		if strings.Contains(name, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// isFreeformSection returns true for sections whose variables are not documented:
+// plugin sections, which plugins define, and per-device input sections, e.g. device:my-mouse or device { name = my-mouse }
+func isFreeformSection(path []string) bool {
+	return path[0] == "plugin" || path[0] == "device" || strings.HasPrefix(path[0], "device:")
+}
+
+// expandVariables replaces references to custom variables in value with their values.
+// ok is false if a referenced variable is not defined, or is defined using other variables.
+func expandVariables(value string, variables map[string]string) (expanded string, ok bool) {
+	ok = true
+	expanded = customVariableReferencePattern.ReplaceAllStringFunc(value, func(reference string) string {
+		variable, defined := variables[strings.TrimPrefix(reference, "$")]
+		if !defined || strings.Contains(variable, "$") {
+			ok = false
+		}
+		return variable
+	})
+	return expanded, ok
+}
+
+func keyRange(assignment parser.Assignment) Range {
+	return Range{
+		Start: assignment.Position,
+		End:   parser.Position{Line: assignment.Position.Line, Column: assignment.Position.Column + utf8.RuneCountInString(assignment.Key)},
+	}
+}
+
+// valueRange returns the range of the assignment's value
+func valueRange(assignment parser.Assignment) Range {
+	// Value.End points to the value's last character, ranges are end-exclusive
+	return Range{
+		Start: assignment.Value.Start,
+		End:   parser.Position{Line: assignment.Value.End.Line, Column: assignment.Value.End.Column + 1},
+	}
+}
+
+// sectionNameRange returns the range of the section's name on the line it starts on
+func sectionNameRange(lines []string, section parser.Section) Range {
+	line := lines[section.Start.Line]
+	start := utf8.RuneCountInString(line[:max(0, strings.Index(line, section.Name))])
+	return Range{
+		Start: parser.Position{Line: section.Start.Line, Column: start},
+		End:   parser.Position{Line: section.Start.Line, Column: start + utf8.RuneCountInString(section.Name)},
+	}
+}
+
+// cutLast slices s around the last instance of sep
+func cutLast(s string, sep string) (before string, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
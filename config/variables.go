@@ -0,0 +1,94 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	parser_data "github.com/ewen-lbh/hyprls/parser/data"
+)
+
+// CustomVariableOccurrence is a definition or a usage of a custom variable
+type CustomVariableOccurrence struct {
+	Name string
+	// Range is the range of the variable's name, not including the $
+	Range Range
+	// Definition is true if this occurrence is the $name = ... line defining the variable
+	Definition bool
+}
+
+// CustomVariableOccurrences returns every definition and usage of custom variables in the file
+func CustomVariableOccurrences(contents string) []CustomVariableOccurrence {
+	occurrences := make([]CustomVariableOccurrence, 0)
+	for i, line := range strings.Split(contents, "\n") {
+		line = StripComment(line)
+		for _, match := range customVariableReferencePattern.FindAllStringSubmatchIndex(line, -1) {
+			occurrences = append(occurrences, CustomVariableOccurrence{
+				Name:       line[match[2]:match[3]],
+				Range:      lineRange(line, i, match[2], match[3]),
+				Definition: isCustomVariableDefinition(line, match[0]),
+			})
+		}
+	}
+	return occurrences
+}
+
+// isCustomVariableDefinition returns true if the $ at index dollarAt is the start of a $name = ... line
+func isCustomVariableDefinition(line string, dollarAt int) bool {
+	if strings.TrimSpace(line[:dollarAt]) != "" {
+		return false
+	}
+	key, _, found := strings.Cut(line, "=")
+	return found && customVariableReferencePattern.FindString(key) == strings.TrimSpace(key)
+}
+
+// inShellCommand returns true if the given column of the line is part of a shell command or environment variable,
+// where $NAME can also refer to an environment variable
+func inShellCommand(line string, column int) bool {
+	key, value, found := strings.Cut(line, "=")
+	if !found {
+		return false
+	}
+
+	key = strings.TrimSpace(key)
+	if strings.HasPrefix(key, "exec") || key == "env" {
+		return true
+	}
+
+	if kw, found := parser_data.FindKeyword(key); found && kw.Name == "bind" {
+		// bind = MODS, key, dispatcher, params
+		args := strings.SplitN(value, ",", 4)
+		if len(args) == 4 && strings.HasPrefix(strings.TrimSpace(args[2]), "exec") {
+			return column > utf8.RuneCountInString(line)-utf8.RuneCountInString(args[3])
+		}
+	}
+	return false
+}
+
+// undefinedCustomVariables warns about custom variables that are defined neither in the file nor in its related files
+func (c Config) undefinedCustomVariables() []Diagnostic {
+	defined := make(map[string]bool)
+	for _, file := range append([]*Config{&c}, c.Related...) {
+		for _, occurrence := range CustomVariableOccurrences(file.source) {
+			if occurrence.Definition {
+				defined[occurrence.Name] = true
+			}
+		}
+	}
+
+	lines := strings.Split(c.source, "\n")
+	diagnostics := make([]Diagnostic, 0)
+	for _, occurrence := range CustomVariableOccurrences(c.source) {
+		if defined[occurrence.Name] || inShellCommand(lines[occurrence.Range.Start.Line], occurrence.Range.Start.Column) {
+			continue
+		}
+
+		diagnostics = append(diagnostics, Diagnostic{
+			Range:    occurrence.Range,
+			Severity: SeverityWarning,
+			Message:  fmt.Sprintf("Variable $%s is not defined", occurrence.Name),
+			Code:     CodeUndefinedVariable,
+		})
+	}
+	return diagnostics
+}
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/ewen-lbh/hyprls/parser"
+	parser_data "github.com/ewen-lbh/hyprls/parser/data"
+)
+
+// unavailableVariables informs about variables that were added in a more recent version of Hyprland than HyprlandVersion
+func (c Config) unavailableVariables() []Diagnostic {
+	diagnostics := make([]Diagnostic, 0)
+	if c.HyprlandVersion == "" {
+		return diagnostics
+	}
+
+	c.Document.WalkAssignments(func(section *parser.Section, assignment *parser.Assignment) {
+		def := parser_data.FindVariableDefinitionInSection(section.Name, assignment.Key)
+		if def == nil || def.AvailableIn(c.HyprlandVersion) {
+			return
+		}
+
+		diagnostics = append(diagnostics, Diagnostic{
+			Range:    keyRange(*assignment),
+			Severity: SeverityInformation,
+			Message:  fmt.Sprintf("%s was added in Hyprland %s, but the installed version is %s", def.Name, def.SinceVersion, c.HyprlandVersion),
+			Code:     CodeUnavailableVariable,
+		})
+	})
+	return diagnostics
+}
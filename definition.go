@@ -0,0 +1,43 @@
+package hyprls
+
+import (
+	"context"
+	"fmt"
+
+	"go.lsp.dev/protocol"
+)
+
+func (h Handler) Definition(ctx context.Context, params *protocol.DefinitionParams) ([]protocol.Location, error) {
+	contents, err := file(params.TextDocument.URI)
+	if err != nil {
+		return nil, fmt.Errorf("while reading file: %w", err)
+	}
+
+	if line, err := currentLine(params.TextDocument.URI, params.Position); err == nil {
+		if submap, found := submapReferenceAt(line, params.Position); found {
+			return submapDefinitions(params.TextDocument.URI, contents, submap), nil
+		}
+	}
+
+	variable, found := customVariableAt(contents, params.Position)
+	if !found {
+		return []protocol.Location{}, nil
+	}
+
+	locations := make([]protocol.Location, 0)
+	for _, definition := range customVariableDefinitions(params.TextDocument.URI, variable.Name) {
+		locations = append(locations, definition.Location())
+	}
+	return locations, nil
+}
+
+// submapDefinitions returns the locations of the submap = NAME lines that start the given submap
+func submapDefinitions(uri protocol.URI, contents string, name string) []protocol.Location {
+	locations := make([]protocol.Location, 0)
+	for _, block := range submapBlocks(contents) {
+		if block.Name == name {
+			locations = append(locations, protocol.Location{URI: uri, Range: block.NameRange})
+		}
+	}
+	return locations
+}
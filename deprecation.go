@@ -0,0 +1,95 @@
+package hyprls
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ewen-lbh/hyprls/parser"
+	parser_data "github.com/ewen-lbh/hyprls/parser/data"
+	"go.lsp.dev/protocol"
+)
+
+type deprecatedAssignment struct {
+	Assignment parser.Assignment
+	Definition *parser_data.VariableDefinition
+	// SectionPath is the full path of the section the assignment is in, e.g. decoration:blur. Empty at the root of the document.
+	SectionPath string
+}
+
+// KeyRange returns the range of the assignment's key
+func (d deprecatedAssignment) KeyRange() protocol.Range {
+	return assignmentKeyRange(d.Assignment)
+}
+
+// deprecatedAssignments returns all assignments to deprecated variables in the document
+func deprecatedAssignments(document parser.Section) []deprecatedAssignment {
+	found := make([]deprecatedAssignment, 0)
+	var walk func(section parser.Section, path []string)
+	walk = func(section parser.Section, path []string) {
+		for _, assignment := range section.Assignments {
+			def := parser_data.FindVariableDefinitionInSection(section.Name, assignment.Key)
+			if def == nil || !def.Deprecated {
+				continue
+			}
+			found = append(found, deprecatedAssignment{
+				Assignment:  assignment,
+				Definition:  def,
+				SectionPath: strings.Join(path, ":"),
+			})
+		}
+		for _, subsection := range section.Subsections {
+			walk(subsection, append(path, strings.ToLower(subsection.Name)))
+		}
+	}
+	walk(document, []string{})
+	return found
+}
+
+// sectionNameRange returns the range of the section's name, line being the line the section starts on
+func sectionNameRange(line string, section parser.Section) protocol.Range {
+	start := max(0, strings.Index(line, section.Name))
+	return protocol.Range{
+		Start: protocol.Position{Line: uint32(section.Start.Line), Character: uint32(runeColumn(line, start))},
+		End:   protocol.Position{Line: uint32(section.Start.Line), Character: uint32(runeColumn(line, start+len(section.Name)))},
+	}
+}
+
+// replaceDeprecatedAssignment returns the edits that replace the deprecated assignment with its replacement.
+// If the replacement is in the same section, only the key is renamed, otherwise the assignment is moved to the end of the document, using the replacement's full path.
+func replaceDeprecatedAssignment(contents string, deprecated deprecatedAssignment) []protocol.TextEdit {
+	replacement := deprecated.Definition.ReplacedWith
+	if deprecated.SectionPath == "" {
+		return []protocol.TextEdit{{Range: deprecated.KeyRange(), NewText: replacement}}
+	}
+
+	if relative, ok := strings.CutPrefix(replacement, deprecated.SectionPath+":"); ok {
+		return []protocol.TextEdit{{Range: deprecated.KeyRange(), NewText: relative}}
+	}
+
+	lines := strings.Split(contents, "\n")
+	lastLine := uint32(len(lines) - 1)
+	moved := fmt.Sprintf("%s = %s", replacement, strings.TrimSpace(deprecated.Assignment.ValueRaw))
+	if lines[lastLine] == "" {
+		moved += "\n"
+	} else {
+		moved = "\n" + moved
+	}
+
+	return []protocol.TextEdit{
+		{
+			Range: protocol.Range{
+				Start: protocol.Position{Line: uint32(deprecated.Assignment.Position.Line), Character: 0},
+				End:   protocol.Position{Line: uint32(deprecated.Assignment.Position.Line + 1), Character: 0},
+			},
+			NewText: "",
+		},
+		{
+			Range: protocol.Range{
+				Start: protocol.Position{Line: lastLine, Character: uint32(utf8.RuneCountInString(lines[lastLine]))},
+				End:   protocol.Position{Line: lastLine, Character: uint32(utf8.RuneCountInString(lines[lastLine]))},
+			},
+			NewText: moved,
+		},
+	}
+}
@@ -0,0 +1,58 @@
+package hyprls
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ewen-lbh/hyprls/config"
+	parser_data "github.com/ewen-lbh/hyprls/parser/data"
+	"go.lsp.dev/protocol"
+	"go.uber.org/zap"
+)
+
+// deprecateSection marks the documented section named name as deprecated until the end of the test.
+// No section of the embedded documentation is deprecated as a whole.
+func deprecateSection(t *testing.T, name string, message string) {
+	t.Helper()
+	sections := parser_data.LoadedSections()
+	i := slices.IndexFunc(sections, func(section parser_data.SectionDefinition) bool { return section.Name() == name })
+	if i == -1 {
+		t.Fatalf("no section named %s", name)
+	}
+
+	original := sections[i]
+	sections[i].Deprecated = true
+	sections[i].DeprecatedMessage = message
+	t.Cleanup(func() { sections[i] = original })
+}
+
+func TestDeprecatedSection(t *testing.T) {
+	resetState(zap.NewNop())
+	deprecateSection(t, "Dwindle", "use the layout section instead")
+
+	document := protocol.URI("file:///tmp/hyprland.conf")
+	contents := "dwindle {\n    pseudotile = true\n}\n"
+	openedFiles[document] = contents
+
+	diagnostics := diagnose(document, contents)
+	index := slices.IndexFunc(diagnostics, func(diagnostic protocol.Diagnostic) bool { return diagnostic.Code == config.CodeDeprecated })
+	if index == -1 {
+		t.Fatalf("expected the section to be reported as deprecated, got %#v", diagnostics)
+	}
+	deprecated := diagnostics[index]
+	if deprecated.Message != "Section dwindle is deprecated, use the layout section instead" || deprecated.Range.Start != (protocol.Position{Line: 0, Character: 0}) {
+		t.Errorf("expected the section's name to be reported with the message, got %#v", deprecated)
+	}
+	if !slices.Contains(deprecated.Tags, protocol.DiagnosticTagDeprecated) {
+		t.Errorf("expected the deprecated tag, got %v", deprecated.Tags)
+	}
+
+	hover := sectionHover(document, "dwindle {", protocol.Position{Line: 0, Character: 2})
+	if hover == nil {
+		t.Fatal("expected a hover on the section's name")
+	}
+	if !strings.Contains(hover.Contents.Value, "**Deprecated**: use the layout section instead") {
+		t.Errorf("expected the deprecation notice in the hover, got %q", hover.Contents.Value)
+	}
+}
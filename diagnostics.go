@@ -0,0 +1,115 @@
+package hyprls
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ewen-lbh/hyprls/config"
+	parser_data "github.com/ewen-lbh/hyprls/parser/data"
+	"go.lsp.dev/protocol"
+	"go.uber.org/zap"
+)
+
+func (h Handler) publishDiagnostics(ctx context.Context, uri protocol.URI) {
+	contents, err := file(uri)
+	if err != nil {
+		logger.Debug("while reading file to diagnose", zap.Error(err))
+		return
+	}
+
+	err = h.Client.PublishDiagnostics(ctx, &protocol.PublishDiagnosticsParams{
+		URI:         uri,
+		Diagnostics: newPositionConverter(true).diagnostics(uri, diagnose(uri, contents)),
+	})
+	if err != nil {
+		logger.Debug("while publishing diagnostics", zap.Error(err))
+	}
+}
+
+// publishIncludedFilesDiagnostics publishes diagnostics for the files sourced by uri that are not opened in the editor,
+// so that mistakes in them show up too
+func (h Handler) publishIncludedFilesDiagnostics(ctx context.Context, uri protocol.URI) {
+	for _, included := range includedFiles(uri) {
+		if _, opened := openedFiles[included]; opened {
+			continue
+		}
+		h.publishDiagnostics(ctx, included)
+	}
+}
+
+// diagnose computes all diagnostics for the given file, see config.Config.Validate
+func diagnose(uri protocol.URI, contents string) []protocol.Diagnostic {
+	cfg, err := config.ParseConfig(strings.NewReader(contents))
+	if err != nil {
+		logger.Debug("while parsing file to diagnose", zap.Error(err))
+		return []protocol.Diagnostic{}
+	}
+
+	cfg.Path = uri.Filename()
+	for _, related := range relatedFiles(uri) {
+		if related == uri {
+			continue
+		}
+		relatedContents, err := file(related)
+		if err != nil {
+			continue
+		}
+		relatedConfig, err := config.ParseConfig(strings.NewReader(relatedContents))
+		if err != nil {
+			continue
+		}
+		relatedConfig.Path = related.Filename()
+		cfg.Related = append(cfg.Related, relatedConfig)
+	}
+
+	// Running hyprctl can take a while, and is useless if the documentation doesn't say when variables were added
+	if parser_data.HasVersionedVariables() {
+		if version, err := installedHyprlandVersion(context.Background()); err == nil {
+			cfg.HyprlandVersion = version
+		} else {
+			logger.Debug("while getting the installed version of Hyprland", zap.Error(err))
+		}
+	}
+
+	diagnostics := make([]protocol.Diagnostic, 0)
+	for _, diagnostic := range cfg.Validate(nil) {
+		switch diagnostic.Code {
+		case config.CodeMissingSource, config.CodeMissingPath:
+			if options.SkipFilesystemChecks {
+				continue
+			}
+		case config.CodeInvalidEnumValue:
+			if !options.EnableEnumValidation {
+				continue
+			}
+		case config.CodeOutOfRange, config.CodeNegativeValue:
+			if !options.EnableTypeChecking {
+				continue
+			}
+		}
+		diagnostics = append(diagnostics, lspDiagnostic(uri, diagnostic))
+	}
+	return diagnostics
+}
+
+// lspDiagnostic converts a diagnostic of the config package, whose related information is in the same file
+func lspDiagnostic(uri protocol.URI, diagnostic config.Diagnostic) protocol.Diagnostic {
+	converted := protocol.Diagnostic{
+		Range: lspRange(diagnostic.Range),
+		// config severities have the same values as LSP ones
+		Severity: protocol.DiagnosticSeverity(diagnostic.Severity),
+		Code:     diagnostic.Code,
+		Source:   "hyprls",
+		Message:  diagnostic.Message,
+	}
+	if diagnostic.Code == config.CodeDeprecated {
+		converted.Tags = []protocol.DiagnosticTag{protocol.DiagnosticTagDeprecated}
+	}
+	for _, related := range diagnostic.Related {
+		converted.RelatedInformation = append(converted.RelatedInformation, protocol.DiagnosticRelatedInformation{
+			Location: protocol.Location{URI: uri, Range: lspRange(related.Range)},
+			Message:  related.Message,
+		})
+	}
+	return converted
+}
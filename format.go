@@ -0,0 +1,38 @@
+package hyprls
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ewen-lbh/hyprls/parser"
+	"go.lsp.dev/protocol"
+)
+
+func (h Handler) Formatting(ctx context.Context, params *protocol.DocumentFormattingParams) ([]protocol.TextEdit, error) {
+	contents, err := file(params.TextDocument.URI)
+	if err != nil {
+		return nil, fmt.Errorf("while reading file: %w", err)
+	}
+
+	formatted, err := parser.Format(contents)
+	if err != nil {
+		return nil, fmt.Errorf("while formatting: %w", err)
+	}
+
+	if formatted == contents {
+		return []protocol.TextEdit{}, nil
+	}
+
+	lines := strings.Split(contents, "\n")
+	lastLine := len(lines) - 1
+	return []protocol.TextEdit{
+		{
+			Range: protocol.Range{
+				End: protocol.Position{Line: uint32(lastLine), Character: uint32(utf8.RuneCountInString(lines[lastLine]))},
+			},
+			NewText: formatted,
+		},
+	}, nil
+}
@@ -5,7 +5,9 @@ go 1.22.2
 require (
 	github.com/PuerkitoBio/goquery v1.5.1
 	github.com/davecgh/go-spew v1.1.1
+	github.com/pmezard/go-difflib v1.0.0
 	go.lsp.dev/jsonrpc2 v0.10.0
+	go.lsp.dev/uri v0.3.0
 	go.uber.org/multierr v1.11.0
 	go.uber.org/zap v1.27.0
 )
@@ -13,7 +15,6 @@ require (
 require (
 	github.com/andybalholm/cascadia v1.1.0 // indirect
 	go.lsp.dev/pkg v0.0.0-20210717090340-384b27a52fb2 // indirect
-	go.lsp.dev/uri v0.3.0 // indirect
 	golang.org/x/net v0.0.0-20200320220750-118fecf932d8 // indirect
 	golang.org/x/text v0.3.0 // indirect
 )
@@ -3,47 +3,81 @@ package hyprls
 import (
 	"context"
 
+	parser_data "github.com/ewen-lbh/hyprls/parser/data"
 	"go.lsp.dev/protocol"
 	"go.uber.org/zap"
 )
 
 type Handler struct {
 	protocol.Server
+	Client protocol.Client
 	Logger *zap.Logger
 }
 
-func NewHandler(ctx context.Context, server protocol.Server, logger *zap.Logger) (Handler, context.Context, error) {
+func NewHandler(ctx context.Context, server protocol.Server, client protocol.Client, logger *zap.Logger) (Handler, context.Context, error) {
 
 	return Handler{
 		Server: server,
+		Client: client,
 		Logger: logger,
 	}, context.WithValue(ctx, "state", state{}), nil
 }
 
 func (h Handler) Initialize(ctx context.Context, params *protocol.InitializeParams) (*protocol.InitializeResult, error) {
 	logger = h.Logger
+	// Parse the documentation while the client finishes initializing, instead of on the first request that needs it
+	go parser_data.EnsureLoaded()
+	if params.InitializationOptions != nil {
+		if err := decodeParams(params.InitializationOptions, &options); err != nil {
+			logger.Debug("while decoding initialization options", zap.Error(err))
+		}
+	}
+	configureLogging()
+	if workspace := params.Capabilities.Workspace; workspace != nil && workspace.DidChangeWatchedFiles != nil {
+		clientCanWatchFiles = workspace.DidChangeWatchedFiles.DynamicRegistration
+	}
 	return &protocol.InitializeResult{
 		Capabilities: protocol.ServerCapabilities{
-			HoverProvider:          true,
-			DocumentSymbolProvider: true,
-			ColorProvider:          true,
+			HoverProvider:              true,
+			DocumentSymbolProvider:     true,
+			ColorProvider:              true,
+			ReferencesProvider:         true,
+			DefinitionProvider:         true,
+			TypeDefinitionProvider:     true,
+			DocumentHighlightProvider:  true,
+			SelectionRangeProvider:     true,
+			DocumentFormattingProvider: true,
+			DocumentLinkProvider: &protocol.DocumentLinkOptions{
+				ResolveProvider: false,
+			},
+			RenameProvider: &protocol.RenameOptions{
+				PrepareProvider: true,
+			},
+			CodeActionProvider: &protocol.CodeActionOptions{
+				CodeActionKinds: []protocol.CodeActionKind{protocol.QuickFix, protocol.RefactorRewrite},
+			},
 			CompletionProvider: &protocol.CompletionOptions{
 				ResolveProvider:   false,
-				TriggerCharacters: []string{},
+				TriggerCharacters: []string{"{", "."},
+			},
+			SignatureHelpProvider: &protocol.SignatureHelpOptions{
+				TriggerCharacters:   []string{"=", ","},
+				RetriggerCharacters: []string{","},
 			},
 			TextDocumentSync: protocol.TextDocumentSyncOptions{
 				OpenClose: true,
-				Change:    protocol.TextDocumentSyncKindFull,
+				Change:    protocol.TextDocumentSyncKindIncremental,
 			},
 		},
 		ServerInfo: &protocol.ServerInfo{
 			Name:    "hyprls",
-			Version: Version,
+			Version: BuildVersion(),
 		},
 	}, nil
 }
 
 func (h Handler) Initialized(ctx context.Context, params *protocol.InitializedParams) error {
+	h.registerInlayHints(ctx)
 	return nil
 }
 
@@ -0,0 +1,76 @@
+package hyprls
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ewen-lbh/hyprls/parser"
+	"go.lsp.dev/protocol"
+)
+
+func (h Handler) DocumentHighlight(ctx context.Context, params *protocol.DocumentHighlightParams) ([]protocol.DocumentHighlight, error) {
+	contents, err := file(params.TextDocument.URI)
+	if err != nil {
+		return nil, fmt.Errorf("while reading file: %w", err)
+	}
+
+	variable, found := customVariableAt(contents, params.Position)
+	if !found {
+		return sectionHighlights(contents, params.Position), nil
+	}
+
+	highlights := make([]protocol.DocumentHighlight, 0)
+	for _, occurrence := range customVariableOccurrences(contents) {
+		if occurrence.Name != variable.Name {
+			continue
+		}
+
+		kind := protocol.DocumentHighlightKindRead
+		if occurrence.Definition {
+			kind = protocol.DocumentHighlightKindWrite
+		}
+		highlights = append(highlights, protocol.DocumentHighlight{
+			Range: occurrence.Range,
+			Kind:  kind,
+		})
+	}
+	return highlights, nil
+}
+
+// sectionHighlights highlights the header of the section whose name is under the cursor,
+// along with the headers of the other blocks of the same section, e.g. every "general {" in the document.
+func sectionHighlights(contents string, position protocol.Position) []protocol.DocumentHighlight {
+	document, err := parser.Parse(contents)
+	if err != nil {
+		return nil
+	}
+
+	lines := strings.Split(contents, "\n")
+	headers := make(map[string][]protocol.Range)
+	var name string
+	var walk func(section parser.Section, path []string)
+	walk = func(section parser.Section, path []string) {
+		for _, subsection := range section.Subsections {
+			subsectionPath := append(slices.Clone(path), strings.ToLower(subsection.Name))
+			key := strings.Join(subsectionPath, ":")
+			header := sectionNameRange(lines[subsection.Start.Line], subsection)
+			headers[key] = append(headers[key], header)
+			if within(header, position) {
+				name = key
+			}
+			walk(subsection, subsectionPath)
+		}
+	}
+	walk(document, []string{})
+
+	highlights := make([]protocol.DocumentHighlight, 0, len(headers[name]))
+	for _, header := range headers[name] {
+		highlights = append(highlights, protocol.DocumentHighlight{
+			Range: header,
+			Kind:  protocol.DocumentHighlightKindText,
+		})
+	}
+	return highlights
+}
@@ -0,0 +1,25 @@
+package hyprls
+
+import (
+	"testing"
+
+	"go.lsp.dev/protocol"
+)
+
+func TestSectionHighlights(t *testing.T) {
+	contents := "general {\n  gaps_in = 5\n}\ndecoration {\n  blur {\n    size = 3\n  }\n}\ngeneral {\n  gaps_out = 10\n}\n"
+
+	highlights := sectionHighlights(contents, protocol.Position{Line: 0, Character: 3})
+	if len(highlights) != 2 || highlights[0].Range.Start.Line != 0 || highlights[1].Range.Start.Line != 8 {
+		t.Errorf("expected both general headers to be highlighted, got %#v", highlights)
+	}
+
+	highlights = sectionHighlights(contents, protocol.Position{Line: 4, Character: 3})
+	if len(highlights) != 1 || highlights[0].Range.Start != (protocol.Position{Line: 4, Character: 2}) || highlights[0].Range.End != (protocol.Position{Line: 4, Character: 6}) {
+		t.Errorf("expected the blur header to be highlighted, got %#v", highlights)
+	}
+
+	if highlights := sectionHighlights(contents, protocol.Position{Line: 1, Character: 3}); len(highlights) != 0 {
+		t.Errorf("expected nothing to be highlighted outside of headers, got %#v", highlights)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/MakeNowJust/heredoc"
+	"github.com/ewen-lbh/hyprls/parser"
 	parser_data "github.com/ewen-lbh/hyprls/parser/data"
 	"go.lsp.dev/protocol"
 )
@@ -16,6 +17,18 @@ func (h Handler) Hover(ctx context.Context, params *protocol.HoverParams) (*prot
 		return nil, fmt.Errorf("while getting current line of file: %w", err)
 	}
 
+	if hover := customVariableHover(params.TextDocument.URI, params.Position); hover != nil {
+		return hover, nil
+	}
+
+	if hover := sectionHover(params.TextDocument.URI, line, params.Position); hover != nil {
+		return hover, nil
+	}
+
+	if hover := ruleHover(line, params.Position); hover != nil {
+		return hover, nil
+	}
+
 	if !strings.Contains(line, "=") {
 		return nil, nil
 	}
@@ -30,25 +43,34 @@ func (h Handler) Hover(ctx context.Context, params *protocol.HoverParams) (*prot
 		return r != ' ' && r != '\t'
 	}) + 1
 
-	for _, section := range parser_data.Sections {
+	for _, section := range parser_data.LoadedSections() {
 		if def := section.VariableDefinition(key); def != nil {
+			allowedValuesLine := ""
+			if len(def.EnumValues) > 0 {
+				allowedValuesLine = fmt.Sprintf("- Allowed values: %s\n", strings.Join(def.EnumValues, ", "))
+			}
+			exampleBlock := ""
+			if def.Example != "" {
+				exampleBlock = fmt.Sprintf("\nExample:\n\n```hyprlang\n%s = %s\n```\n", def.Name, def.Example)
+			}
 			return &protocol.Hover{
 				Contents: protocol.MarkupContent{
 					Kind: protocol.Markdown,
-					Value: heredoc.Docf(`### %s: %s (%s)
+					Value: heredoc.Docf(`### %s: %s
 						%s
 						
+						- Type: %s
 						- Defaults to: %s
-					`, strings.Join(section.Path, ":"), def.Name, def.Type, def.Description, def.PrettyDefault()),
+					`, strings.Join(section.Path, ":"), def.Name, def.Description, def.PrettyType(), def.PrettyDefault()) + allowedValuesLine + exampleBlock,
 				},
 				Range: &protocol.Range{
 					Start: protocol.Position{
 						Line:      params.Position.Line,
-						Character: uint32(indexOfFirstNonWhitespace),
+						Character: uint32(runeColumn(line, indexOfFirstNonWhitespace)),
 					},
 					End: protocol.Position{
 						Line:      params.Position.Line,
-						Character: uint32(indexOfLastNonWhitespace),
+						Character: uint32(runeColumn(line, indexOfLastNonWhitespace)),
 					},
 				},
 			}, nil
@@ -57,19 +79,23 @@ func (h Handler) Hover(ctx context.Context, params *protocol.HoverParams) (*prot
 			if len(kw.Flags) > 0 {
 				flagsLine = fmt.Sprintf("\n- Accepts the following flags: %s\n", strings.Join(kw.Flags, ", "))
 			}
+			description := kw.Description
+			if kw.Name == "monitor" {
+				description = monitorFieldsDocumentation() + "\n" + description
+			}
 			return &protocol.Hover{
 				Contents: protocol.MarkupContent{
 					Kind:  protocol.Markdown,
-					Value: fmt.Sprintf("### %s [[docs]](%s)%s\n%s", kw.Name, kw.DocumentationLink(), flagsLine, kw.Description),
+					Value: fmt.Sprintf("### %s [[docs]](%s)%s\n%s", kw.Name, kw.DocumentationLink(), flagsLine, description),
 				},
 				Range: &protocol.Range{
 					Start: protocol.Position{
 						Line:      params.Position.Line,
-						Character: uint32(indexOfFirstNonWhitespace),
+						Character: uint32(runeColumn(line, indexOfFirstNonWhitespace)),
 					},
 					End: protocol.Position{
 						Line:      params.Position.Line,
-						Character: uint32(indexOfLastNonWhitespace),
+						Character: uint32(runeColumn(line, indexOfLastNonWhitespace)),
 					},
 				},
 			}, nil
@@ -78,3 +104,76 @@ func (h Handler) Hover(ctx context.Context, params *protocol.HoverParams) (*prot
 
 	return nil, nil
 }
+
+// customVariableHover shows the value and type of the custom variable under the cursor, if any
+func customVariableHover(uri protocol.URI, position protocol.Position) *protocol.Hover {
+	contents, err := file(uri)
+	if err != nil {
+		return nil
+	}
+
+	variable, found := customVariableAt(contents, position)
+	if !found {
+		return nil
+	}
+
+	definition, kind, found := customVariableTypeDefinition(uri, variable.Name)
+	if !found {
+		return nil
+	}
+
+	typeNote := fmt.Sprintf("Type: %s", kind)
+	if definition.Name != variable.Name {
+		typeNote += fmt.Sprintf(", from $%s", definition.Name)
+	}
+
+	return &protocol.Hover{
+		Contents: protocol.MarkupContent{
+			Kind:  protocol.Markdown,
+			Value: fmt.Sprintf("### $%s\n%s\n\n```hyprlang\n$%s = %s\n```\n", variable.Name, typeNote, definition.Name, customVariableValue(definition)),
+		},
+		Range: &variable.Range,
+	}
+}
+
+// sectionHover summarizes the section whose name is under the cursor, with its deprecation notice if it is deprecated
+func sectionHover(uri protocol.URI, line string, position protocol.Position) *protocol.Hover {
+	document, err := parse(uri)
+	if err != nil {
+		return nil
+	}
+
+	var hover *protocol.Hover
+	var walk func(section parser.Section)
+	walk = func(section parser.Section) {
+		for _, subsection := range section.Subsections {
+			walk(subsection)
+			nameRange := sectionNameRange(line, subsection)
+			if hover != nil || subsection.Start.Line != int(position.Line) || !within(nameRange, position) {
+				continue
+			}
+
+			def := parser_data.FindSectionDefinitionByName(subsection.Name)
+			if def == nil {
+				continue
+			}
+
+			summary := fmt.Sprintf("%d variables", def.VariableCount())
+			if def.Deprecated {
+				summary = "**Deprecated**"
+				if def.DeprecatedMessage != "" {
+					summary += ": " + def.DeprecatedMessage
+				}
+			}
+			hover = &protocol.Hover{
+				Contents: protocol.MarkupContent{
+					Kind:  protocol.Markdown,
+					Value: fmt.Sprintf("### %s\n%s", strings.Join(def.Path, ":"), summary),
+				},
+				Range: &nameRange,
+			}
+		}
+	}
+	walk(document)
+	return hover
+}
@@ -0,0 +1,75 @@
+package hyprls
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// hyprctlTimeout is how long hyprctl gets to answer, so that an unresponsive Hyprland doesn't hold up requests
+const hyprctlTimeout = 2 * time.Second
+
+type hyprctlMonitor struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Make        string `json:"make"`
+	Model       string `json:"model"`
+}
+
+// hyprctlMonitors returns the monitors currently connected, as reported by hyprctl
+func hyprctlMonitors(ctx context.Context) ([]hyprctlMonitor, error) {
+	ctx, cancel := context.WithTimeout(ctx, hyprctlTimeout)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, hyprctlExecutable(), "monitors", "-j").Output()
+	if err != nil {
+		return nil, fmt.Errorf("while running hyprctl: %w", err)
+	}
+
+	var monitors []hyprctlMonitor
+	if err := json.Unmarshal(output, &monitors); err != nil {
+		return nil, fmt.Errorf("while decoding hyprctl output: %w", err)
+	}
+	return monitors, nil
+}
+
+var hyprctlVersionPattern = regexp.MustCompile(`\d+(?:\.\d+)+`)
+
+var installedVersion struct {
+	once    sync.Once
+	version string
+	err     error
+}
+
+// installedHyprlandVersion returns the version of the running Hyprland, e.g. 0.40.0, as reported by hyprctl.
+// hyprctl is only run once, as diagnostics need the version on every change.
+func installedHyprlandVersion(ctx context.Context) (string, error) {
+	installedVersion.once.Do(func() {
+		ctx, cancel := context.WithTimeout(ctx, hyprctlTimeout)
+		defer cancel()
+
+		output, err := exec.CommandContext(ctx, hyprctlExecutable(), "version", "-j").Output()
+		if err != nil {
+			installedVersion.err = fmt.Errorf("while running hyprctl: %w", err)
+			return
+		}
+
+		var version struct {
+			Tag string `json:"tag"`
+		}
+		if err := json.Unmarshal(output, &version); err != nil {
+			installedVersion.err = fmt.Errorf("while decoding hyprctl output: %w", err)
+			return
+		}
+
+		installedVersion.version = hyprctlVersionPattern.FindString(version.Tag)
+		if installedVersion.version == "" {
+			installedVersion.err = fmt.Errorf("no version number in tag %q", version.Tag)
+		}
+	})
+	return installedVersion.version, installedVersion.err
+}
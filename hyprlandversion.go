@@ -0,0 +1,24 @@
+package hyprls
+
+import (
+	"regexp"
+
+	"go.lsp.dev/protocol"
+)
+
+// hyprlandVersionDirectivePattern matches the #!hyprland-version: N.NN directive, that tells which version of Hyprland the config is written for
+var hyprlandVersionDirectivePattern = regexp.MustCompile(`(?m)^\s*#!\s*hyprland-version:\s*v?(\d+(?:\.\d+)*)\s*$`)
+
+// pinnedHyprlandVersion returns the version given by the document's #!hyprland-version directive, if any
+func pinnedHyprlandVersion(uri protocol.URI) (string, bool) {
+	contents, err := file(uri)
+	if err != nil {
+		return "", false
+	}
+
+	match := hyprlandVersionDirectivePattern.FindStringSubmatch(contents)
+	if match == nil {
+		return "", false
+	}
+	return match[1], true
+}
@@ -0,0 +1,139 @@
+package hyprls
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/ewen-lbh/hyprls/config"
+	"go.lsp.dev/protocol"
+	"go.lsp.dev/uri"
+)
+
+type sourceDirective struct {
+	// Path is the path as written in the file
+	Path string
+	// Range is the range of the path in the file
+	Range protocol.Range
+}
+
+// sourceDirectives returns all the source = ... lines of a file
+func sourceDirectives(contents string) []sourceDirective {
+	directives := make([]sourceDirective, 0)
+	for _, directive := range config.SourceDirectives(contents) {
+		directives = append(directives, sourceDirective{Path: directive.Path, Range: lspRange(directive.Range)})
+	}
+	return directives
+}
+
+// resolveSourcePath resolves a path given to source = ..., relative to the file it was written in, see config.ResolveSourcePath
+func resolveSourcePath(from protocol.URI, path string) string {
+	return config.ResolveSourcePath(from.Filename(), path)
+}
+
+// sourcePathCompletions proposes the .conf files and directories in the directory being typed in a source = PATH line.
+// Relative paths are relative to the directory of the file being edited.
+func sourcePathCompletions(from protocol.URI, line string, position protocol.Position) (items []protocol.CompletionItem, ok bool) {
+	beforeCursor := textBeforeCursor(line, position)
+	_, typed, found := strings.Cut(beforeCursor, "=")
+	if !found {
+		return nil, false
+	}
+	typed = strings.TrimLeftFunc(typed, unicode.IsSpace)
+
+	directory := filepath.Dir(from.Filename())
+	typedDirectory, typedName := "", typed
+	if slash := strings.LastIndex(typed, "/"); slash != -1 {
+		typedDirectory, typedName = typed[:slash+1], typed[slash+1:]
+		directory = resolveSourcePath(from, typedDirectory)
+	}
+
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		return nil, false
+	}
+
+	items = make([]protocol.CompletionItem, 0)
+	for _, entry := range entries {
+		name := entry.Name()
+		// Only propose hidden files when the user asks for them
+		if !strings.HasPrefix(name, typedName) || strings.HasPrefix(name, ".") && !strings.HasPrefix(typedName, ".") {
+			continue
+		}
+
+		isDirectory := entry.IsDir()
+		if entry.Type()&os.ModeSymlink != 0 {
+			if info, err := os.Stat(filepath.Join(directory, name)); err == nil {
+				isDirectory = info.IsDir()
+			}
+		}
+
+		kind := protocol.CompletionItemKindFile
+		if isDirectory {
+			kind = protocol.CompletionItemKindFolder
+			name += "/"
+		} else if filepath.Ext(name) != ".conf" {
+			continue
+		}
+
+		item := wordCompletion(name, kind, typedName, position)
+		item.Detail = typedDirectory + name
+		items = append(items, item)
+	}
+	return items, true
+}
+
+// includedFiles returns the URIs of all files sourced by the given file, recursively.
+// The given file is not part of the result.
+// Returns nothing if NoWorkspaceScan is set.
+func includedFiles(root protocol.URI) []protocol.URI {
+	if NoWorkspaceScan {
+		return []protocol.URI{}
+	}
+
+	read := func(path string) (string, error) {
+		return file(uri.File(path))
+	}
+
+	included := make([]protocol.URI, 0)
+	for _, path := range config.SourcedFiles(root.Filename(), read) {
+		included = append(included, uri.File(path))
+	}
+	return included
+}
+
+// relatedFiles returns uri, the files it sources, and the opened files or main configuration file that source it, along with the files they source.
+// These are the files where custom variables used in uri might be defined.
+func relatedFiles(uri protocol.URI) []protocol.URI {
+	related := []protocol.URI{uri}
+	seen := map[protocol.URI]bool{uri: true}
+	add := func(files ...protocol.URI) {
+		for _, f := range files {
+			if !seen[f] {
+				seen[f] = true
+				related = append(related, f)
+			}
+		}
+	}
+
+	add(includedFiles(uri)...)
+	// The main configuration file usually sources the others, even when it is not opened
+	candidates := []protocol.URI{mainConfigFile()}
+	for opened := range openedFiles {
+		candidates = append(candidates, opened)
+	}
+	for _, candidate := range candidates {
+		if candidate == uri || candidate == "" {
+			continue
+		}
+		for _, included := range includedFiles(candidate) {
+			if included == uri {
+				add(candidate)
+				add(includedFiles(candidate)...)
+				break
+			}
+		}
+	}
+	return related
+}
@@ -0,0 +1,207 @@
+package hyprls
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ewen-lbh/hyprls/parser"
+	parser_data "github.com/ewen-lbh/hyprls/parser/data"
+	"go.lsp.dev/protocol"
+	"go.uber.org/zap"
+)
+
+type InlayHintParams struct {
+	TextDocument protocol.TextDocumentIdentifier `json:"textDocument"`
+	Range        protocol.Range                  `json:"range"`
+}
+
+type InlayHint struct {
+	Position     protocol.Position       `json:"position"`
+	Label        string                  `json:"label"`
+	Tooltip      *protocol.MarkupContent `json:"tooltip,omitempty"`
+	PaddingLeft  bool                    `json:"paddingLeft,omitempty"`
+	PaddingRight bool                    `json:"paddingRight,omitempty"`
+	Data         interface{}             `json:"data,omitempty"`
+}
+
+// inlayHintData is stored in InlayHint.Data to resolve the hint's tooltip later on
+type inlayHintData struct {
+	Section  string `json:"section"`
+	Variable string `json:"variable"`
+}
+
+// registerInlayHints registers the inlay hint capability, which is not part of protocol.ServerCapabilities
+func (h Handler) registerInlayHints(ctx context.Context) {
+	// The client's response can only be read once we're done handling the current message
+	go func() {
+		err := h.Client.RegisterCapability(ctx, &protocol.RegistrationParams{
+			Registrations: []protocol.Registration{
+				{
+					ID:     "inlay-hints",
+					Method: methodInlayHint,
+					RegisterOptions: map[string]interface{}{
+						"resolveProvider":  true,
+						"documentSelector": nil,
+					},
+				},
+			},
+		})
+		if err != nil {
+			logger.Debug("while registering inlay hints", zap.Error(err))
+		}
+	}()
+}
+
+func (h Handler) InlayHint(ctx context.Context, params *InlayHintParams) ([]InlayHint, error) {
+	document, err := parse(params.TextDocument.URI)
+	if err != nil {
+		return nil, fmt.Errorf("while parsing: %w", err)
+	}
+
+	hints := make([]InlayHint, 0)
+	document.WalkAssignments(func(section *parser.Section, assignment *parser.Assignment) {
+		if assignment.Position.Line < int(params.Range.Start.Line) || assignment.Position.Line > int(params.Range.End.Line) {
+			return
+		}
+
+		def := parser_data.FindVariableDefinitionInSection(section.Name, assignment.Key)
+		if def == nil || sameValue(assignment.ValueRaw, def.Default) {
+			return
+		}
+
+		defaultValue := def.Default
+		if defaultValue == "[[Empty]]" {
+			defaultValue = "empty"
+		}
+
+		hints = append(hints, InlayHint{
+			Position: protocol.Position{
+				Line:      uint32(assignment.Value.End.Line),
+				Character: uint32(assignment.Value.End.Column + 1),
+			},
+			Label:       fmt.Sprintf("(default: %s)", defaultValue),
+			PaddingLeft: true,
+			Data: inlayHintData{
+				Section:  section.Name,
+				Variable: assignment.Key,
+			},
+		})
+	})
+
+	hints = append(hints, customVariableValueHints(params.TextDocument.URI, params.Range)...)
+	hints = append(hints, sectionPathHints(params.TextDocument.URI, document, params.Range)...)
+	return hints, nil
+}
+
+// sectionPathHints shows the full path of nested sections after their opening brace, e.g. decoration:blur after blur {
+func sectionPathHints(uri protocol.URI, document parser.Section, rang protocol.Range) []InlayHint {
+	contents, err := file(uri)
+	if err != nil {
+		return nil
+	}
+
+	lines := strings.Split(contents, "\n")
+	hints := make([]InlayHint, 0)
+	var walk func(section parser.Section, path []string)
+	walk = func(section parser.Section, path []string) {
+		for _, subsection := range section.Subsections {
+			subsectionPath := append(slices.Clone(path), strings.ToLower(subsection.Name))
+			walk(subsection, subsectionPath)
+			if len(subsectionPath) < 2 || subsection.Start.Line < int(rang.Start.Line) || subsection.Start.Line > int(rang.End.Line) {
+				continue
+			}
+
+			brace := sectionOpeningBrace(lines[subsection.Start.Line], subsection)
+			hints = append(hints, InlayHint{
+				Position:    protocol.Position{Line: brace.Line, Character: brace.Character + 1},
+				Label:       strings.Join(subsectionPath, ":"),
+				PaddingLeft: true,
+			})
+		}
+	}
+	walk(document, []string{})
+	return hints
+}
+
+// maxInlayHintValueLength is the length after which values shown in inlay hints are truncated
+const maxInlayHintValueLength = 30
+
+// customVariableValueHints shows the value of custom variables next to their usages, e.g. $accent [=rgba(ff0000ff)].
+// Definitions of the form $a = $b are followed, see customVariableTypeDefinition.
+func customVariableValueHints(uri protocol.URI, rang protocol.Range) []InlayHint {
+	contents, err := file(uri)
+	if err != nil {
+		return nil
+	}
+
+	hints := make([]InlayHint, 0)
+	for _, occurrence := range customVariableOccurrences(contents) {
+		if occurrence.Definition || occurrence.Range.Start.Line < rang.Start.Line || occurrence.Range.Start.Line > rang.End.Line {
+			continue
+		}
+
+		definition, _, found := customVariableTypeDefinition(uri, occurrence.Name)
+		if !found {
+			continue
+		}
+
+		value := customVariableValue(definition)
+		if utf8.RuneCountInString(value) > maxInlayHintValueLength {
+			value = string([]rune(value)[:maxInlayHintValueLength]) + "…"
+		}
+
+		hints = append(hints, InlayHint{
+			Position:    occurrence.Range.End,
+			Label:       fmt.Sprintf("[=%s]", value),
+			PaddingLeft: true,
+		})
+	}
+	return hints
+}
+
+func (h Handler) InlayHintResolve(ctx context.Context, hint *InlayHint) (*InlayHint, error) {
+	var data inlayHintData
+	if err := decodeParams(hint.Data, &data); err != nil {
+		return hint, nil
+	}
+
+	def := parser_data.FindVariableDefinitionInSection(data.Section, data.Variable)
+	if def == nil {
+		return hint, nil
+	}
+
+	hint.Tooltip = &protocol.MarkupContent{
+		Kind:  protocol.Markdown,
+		Value: fmt.Sprintf("**%s** (%s)\n\n%s", def.Name, def.Type, def.Description),
+	}
+	return hint, nil
+}
+
+// sameValue returns true if value and defaultValue are equivalent, i.e. 1.0 and 1, or on and true
+func sameValue(value string, defaultValue string) bool {
+	value = strings.TrimSpace(value)
+	defaultValue = strings.TrimSpace(defaultValue)
+	if defaultValue == "[[Empty]]" {
+		defaultValue = ""
+	}
+
+	if value == defaultValue {
+		return true
+	}
+
+	if a, err := strconv.ParseFloat(value, 64); err == nil {
+		b, err := strconv.ParseFloat(defaultValue, 64)
+		return err == nil && a == b
+	}
+
+	if a, err := parser.ParseBool(value); err == nil {
+		b, err := parser.ParseBool(defaultValue)
+		return err == nil && a == b
+	}
+
+	return false
+}
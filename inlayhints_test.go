@@ -0,0 +1,42 @@
+package hyprls
+
+import (
+	"testing"
+
+	"go.lsp.dev/protocol"
+)
+
+func TestCustomVariableValueHints(t *testing.T) {
+	uri := protocol.URI("file:///tmp/hyprls-inlay-hints.conf")
+	openedFiles[uri] = "$red = rgba(ff0000ff)\n$accent = $red\ngeneral {\n  col.active_border = $accent\n  col.inactive_border = $undefined\n}\n"
+	defer delete(openedFiles, uri)
+
+	hints := customVariableValueHints(uri, protocol.Range{End: protocol.Position{Line: 10}})
+	if len(hints) != 2 {
+		t.Fatalf("expected hints for $red and $accent, got %#v", hints)
+	}
+
+	if hints[0].Label != "[=rgba(ff0000ff)]" || hints[0].Position != (protocol.Position{Line: 1, Character: 14}) {
+		t.Errorf("expected the value of $red after it, got %#v", hints[0])
+	}
+
+	if hints[1].Label != "[=rgba(ff0000ff)]" || hints[1].Position != (protocol.Position{Line: 3, Character: 29}) {
+		t.Errorf("expected $accent to be resolved to the value of $red, got %#v", hints[1])
+	}
+}
+
+func TestSectionPathHints(t *testing.T) {
+	uri := protocol.URI("file:///tmp/hyprls-section-path-hints.conf")
+	openedFiles[uri] = "decoration {\n  rounding = 5\n  blur {\n    size = 3\n  }\n}\n"
+	defer delete(openedFiles, uri)
+
+	document, err := parse(uri)
+	if err != nil {
+		t.Fatalf("while parsing: %s", err)
+	}
+
+	hints := sectionPathHints(uri, document, protocol.Range{End: protocol.Position{Line: 10}})
+	if len(hints) != 1 || hints[0].Label != "decoration:blur" || hints[0].Position != (protocol.Position{Line: 2, Character: 8}) {
+		t.Errorf("expected a single hint after the opening brace of blur, got %#v", hints)
+	}
+}
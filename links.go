@@ -0,0 +1,27 @@
+package hyprls
+
+import (
+	"context"
+	"fmt"
+
+	"go.lsp.dev/protocol"
+	"go.lsp.dev/uri"
+)
+
+func (h Handler) DocumentLink(ctx context.Context, params *protocol.DocumentLinkParams) ([]protocol.DocumentLink, error) {
+	contents, err := file(params.TextDocument.URI)
+	if err != nil {
+		return nil, fmt.Errorf("while reading file: %w", err)
+	}
+
+	links := make([]protocol.DocumentLink, 0)
+	for _, directive := range sourceDirectives(contents) {
+		path := resolveSourcePath(params.TextDocument.URI, directive.Path)
+		links = append(links, protocol.DocumentLink{
+			Range:   directive.Range,
+			Target:  uri.File(path),
+			Tooltip: path,
+		})
+	}
+	return links, nil
+}
@@ -2,9 +2,12 @@ package hyprls
 
 import (
 	"context"
+	"fmt"
 	"io"
+	"net"
 	"os"
 	"path/filepath"
+	"runtime/debug"
 
 	"go.lsp.dev/jsonrpc2"
 	"go.lsp.dev/protocol"
@@ -12,21 +15,68 @@ import (
 	"go.uber.org/zap"
 )
 
+// Version is the version of hyprls, set at build time with -ldflags "-X github.com/ewen-lbh/hyprls.Version=...". See BuildVersion.
 var Version string
 
+// BuildVersion returns Version, or the version of the module recorded by go install when it is not set, e.g. v0.2.0 or (devel)
+func BuildVersion() string {
+	if Version != "" {
+		return Version
+	}
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" {
+		return info.Main.Version
+	}
+	return "(devel)"
+}
+
+// NoWorkspaceScan disables the discovery of files sourced by the opened ones: each file is handled on its own.
+var NoWorkspaceScan bool
+
+// StartServer serves LSP over the standard input and output
 func StartServer(logger *zap.Logger, logClientIn string) {
 	logger.Debug("starting server")
-	conn := jsonrpc2.NewConn(jsonrpc2.NewStream(&readWriteCloser{
+	serve(logger, &readWriteCloser{
 		reader: os.Stdin,
 		writer: os.Stdout,
 		logAt:  logClientIn,
-	}))
-	handler, ctx, err := NewHandler(context.Background(), protocol.ServerDispatcher(conn, logger), logger)
+	})
+}
+
+// ListenAndServe serves LSP over TCP on the given address, e.g. :7000.
+// Clients are served one after the other, as the server's state is shared.
+func ListenAndServe(logger *zap.Logger, address string, logClientIn string) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return fmt.Errorf("while listening on %s: %w", address, err)
+	}
+	defer listener.Close()
+
+	logger.Info("listening", zap.String("address", listener.Addr().String()))
+	for {
+		client, err := listener.Accept()
+		if err != nil {
+			return fmt.Errorf("while accepting a connection: %w", err)
+		}
+
+		logger.Debug("client connected", zap.String("address", client.RemoteAddr().String()))
+		serve(logger, &readWriteCloser{
+			reader: client,
+			writer: client,
+			logAt:  logClientIn,
+		})
+	}
+}
+
+// serve handles LSP messages from the given stream until the connection is closed
+func serve(logger *zap.Logger, stream io.ReadWriteCloser) {
+	resetState(logger)
+	conn := jsonrpc2.NewConn(jsonrpc2.NewStream(stream))
+	handler, ctx, err := NewHandler(context.Background(), protocol.ServerDispatcher(conn, logger), protocol.ClientDispatcher(conn, logger), logger)
 	if err != nil {
 		logger.Sugar().Fatalf("while initializing handler: %w", err)
 	}
 
-	conn.Go(ctx, protocol.ServerHandler(handler, jsonrpc2.MethodNotFoundHandler))
+	conn.Go(ctx, withUTF16Positions(withLabelDetails(withFullContentChanges(handler, protocol.ServerHandler(handler, jsonrpc2.MethodNotFoundHandler)))))
 	<-conn.Done()
 }
 
@@ -61,5 +111,9 @@ func (r *readWriteCloser) Write(b []byte) (int, error) {
 }
 
 func (r *readWriteCloser) Close() error {
+	// Connections are both the reader and the writer
+	if io.Closer(r.reader) == io.Closer(r.writer) {
+		return r.reader.Close()
+	}
 	return multierr.Append(r.reader.Close(), r.writer.Close())
 }
@@ -0,0 +1,36 @@
+package hyprls
+
+import (
+	"bytes"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+type countingConn struct {
+	bytes.Buffer
+	closed int
+}
+
+func (c *countingConn) Close() error {
+	c.closed++
+	return nil
+}
+
+func TestCloseConnectionOnce(t *testing.T) {
+	conn := &countingConn{}
+	if err := (&readWriteCloser{reader: conn, writer: conn}).Close(); err != nil || conn.closed != 1 {
+		t.Errorf("expected the connection to be closed once, got %d closes (%v)", conn.closed, err)
+	}
+}
+
+func TestResetState(t *testing.T) {
+	openedFiles["file:///tmp/hyprls-test/previous-client.conf"] = "general {\n}\n"
+	options.EnableTypeChecking = false
+	diskFiles["file:///tmp/hyprls-test/sourced.conf"] = "general {\n}\n"
+
+	resetState(zap.NewNop())
+	if len(openedFiles) != 0 || len(diskFiles) != 0 || options != defaultOptions {
+		t.Errorf("expected the previous client's state to be forgotten, got %v, %v and %#v", openedFiles, diskFiles, options)
+	}
+}
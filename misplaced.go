@@ -0,0 +1,112 @@
+package hyprls
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ewen-lbh/hyprls/parser"
+	parser_data "github.com/ewen-lbh/hyprls/parser/data"
+	"go.lsp.dev/protocol"
+)
+
+type misplacedAssignment struct {
+	Assignment parser.Assignment
+	// Section is the root section that defines the assigned variable
+	Section parser_data.SectionDefinition
+}
+
+// misplacedAssignments returns the assignments at the root of the document to variables that belong in a section,
+// e.g. gaps_in = 5 instead of general { gaps_in = 5 }.
+// Variables defined by several sections are left out, since there is no telling which one was meant.
+func misplacedAssignments(document parser.Section) []misplacedAssignment {
+	rootSections := make([]parser_data.SectionDefinition, 0)
+	for _, section := range parser_data.LoadedSections() {
+		if len(section.Path) == 1 {
+			rootSections = append(rootSections, section)
+		}
+	}
+
+	found := make([]misplacedAssignment, 0)
+	for _, assignment := range document.Assignments {
+		// general:gaps_in = 5 is a valid way to assign a variable outside of its section
+		if strings.Contains(assignment.Key, ":") || parser_data.IsRootVariable(assignment.Key) {
+			continue
+		}
+
+		definedIn := make([]parser_data.SectionDefinition, 0, 1)
+		for _, section := range rootSections {
+			if section.VariableDefinition(assignment.Key) != nil {
+				definedIn = append(definedIn, section)
+			}
+		}
+
+		if len(definedIn) == 1 {
+			found = append(found, misplacedAssignment{Assignment: assignment, Section: definedIn[0]})
+		}
+	}
+	return found
+}
+
+// existingSection returns the section of the document's root that has the given name, if any
+func existingSection(document parser.Section, name string) *parser.Section {
+	for i, section := range document.Subsections {
+		if strings.ToLower(section.Name) == name {
+			return &document.Subsections[i]
+		}
+	}
+	return nil
+}
+
+// moveIntoSectionAction returns the code action that moves the misplaced assignment into its section:
+// into the existing section block if the document has one, or wrapped in a new section block otherwise.
+func moveIntoSectionAction(uri protocol.DocumentURI, contents string, document parser.Section, misplaced misplacedAssignment) protocol.CodeAction {
+	lines := strings.Split(contents, "\n")
+	line := misplaced.Assignment.Position.Line
+	name := misplaced.Section.JSONName()
+	assignmentLine := strings.TrimSpace(lines[line])
+
+	var title string
+	var edits []protocol.TextEdit
+	if section := existingSection(document, name); section != nil && section.End.Line > section.Start.Line {
+		title = fmt.Sprintf("Move into the `%s { }` section", name)
+		indentation := "    "
+		if len(section.Assignments) > 0 {
+			first := lines[section.Assignments[0].Position.Line]
+			indentation = first[:len(first)-len(strings.TrimLeft(first, " \t"))]
+		}
+		edits = []protocol.TextEdit{
+			{
+				Range: protocol.Range{
+					Start: protocol.Position{Line: uint32(line), Character: 0},
+					End:   protocol.Position{Line: uint32(line + 1), Character: 0},
+				},
+				NewText: "",
+			},
+			{
+				Range:   collapsedRange(protocol.Position{Line: uint32(section.End.Line), Character: 0}),
+				NewText: indentation + assignmentLine + "\n",
+			},
+		}
+	} else {
+		title = fmt.Sprintf("Wrap in `%s { }` section", name)
+		edits = []protocol.TextEdit{
+			{
+				Range: protocol.Range{
+					Start: protocol.Position{Line: uint32(line), Character: 0},
+					End:   protocol.Position{Line: uint32(line), Character: uint32(utf8.RuneCountInString(lines[line]))},
+				},
+				NewText: fmt.Sprintf("%s {\n    %s\n}", name, assignmentLine),
+			},
+		}
+	}
+
+	return protocol.CodeAction{
+		Title:       title,
+		Kind:        protocol.QuickFix,
+		IsPreferred: true,
+		Edit: &protocol.WorkspaceEdit{
+			Changes: map[protocol.DocumentURI][]protocol.TextEdit{uri: edits},
+		},
+	}
+}
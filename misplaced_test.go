@@ -0,0 +1,45 @@
+package hyprls
+
+import (
+	"testing"
+
+	"github.com/ewen-lbh/hyprls/parser"
+)
+
+func TestMoveIntoSectionAction(t *testing.T) {
+	contents := "gaps_in = 5\n"
+	document, err := parser.Parse(contents)
+	if err != nil {
+		t.Fatalf("while parsing: %s", err)
+	}
+
+	misplaced := misplacedAssignments(document)
+	if len(misplaced) != 1 || misplaced[0].Section.JSONName() != "general" {
+		t.Fatalf("expected gaps_in to belong in the general section, got %#v", misplaced)
+	}
+
+	action := moveIntoSectionAction("file:///hyprland.conf", contents, document, misplaced[0])
+	edits := action.Edit.Changes["file:///hyprland.conf"]
+	if action.Title != "Wrap in `general { }` section" || len(edits) != 1 || edits[0].NewText != "general {\n    gaps_in = 5\n}" {
+		t.Errorf("expected the assignment to be wrapped in a new section, got %q with %#v", action.Title, edits)
+	}
+}
+
+func TestMoveIntoExistingSectionAction(t *testing.T) {
+	contents := "general {\n\tborder_size = 2\n}\ngaps_in = 5\n"
+	document, err := parser.Parse(contents)
+	if err != nil {
+		t.Fatalf("while parsing: %s", err)
+	}
+
+	misplaced := misplacedAssignments(document)
+	if len(misplaced) != 1 {
+		t.Fatalf("expected one misplaced assignment, got %#v", misplaced)
+	}
+
+	action := moveIntoSectionAction("file:///hyprland.conf", contents, document, misplaced[0])
+	edits := action.Edit.Changes["file:///hyprland.conf"]
+	if len(edits) != 2 || edits[0].Range.Start.Line != 3 || edits[1].Range.Start.Line != 2 || edits[1].NewText != "\tgaps_in = 5\n" {
+		t.Errorf("expected the assignment to be moved before the closing brace of the general section, got %#v", edits)
+	}
+}
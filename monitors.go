@@ -0,0 +1,47 @@
+package hyprls
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	parser_data "github.com/ewen-lbh/hyprls/parser/data"
+	"go.lsp.dev/protocol"
+)
+
+// monitorKeywordCompletions proposes the special values of the field of the monitor = ... line the cursor is in.
+// ok is false if the field has none.
+func monitorKeywordCompletions(line string, position protocol.Position) (items []protocol.CompletionItem, ok bool) {
+	beforeCursor := textBeforeCursor(line, position)
+	field, ok := argumentIndex(line, position)
+	if !ok || field >= len(parser_data.MonitorFields) || len(parser_data.MonitorFields[field].Keywords) == 0 {
+		return nil, false
+	}
+
+	typed := typedWord(beforeCursor, func(r rune) bool { return !unicode.IsSpace(r) && r != ',' })
+	for i, keyword := range parser_data.MonitorFields[field].Keywords {
+		item := wordCompletion(keyword.Value, protocol.CompletionItemKindKeyword, typed, position)
+		item.Detail = keyword.Description
+		item.SortText = fmt.Sprintf("%03d", i)
+		items = append(items, item)
+	}
+	return items, true
+}
+
+// monitorFieldsDocumentation explains the fields of monitor = ... lines, for the keyword's hover
+func monitorFieldsDocumentation() string {
+	names := make([]string, 0, len(parser_data.MonitorFields))
+	for _, field := range parser_data.MonitorFields {
+		names = append(names, strings.ToUpper(field.Name))
+	}
+
+	documentation := fmt.Sprintf("```hyprlang\nmonitor = %s\n```\n\n", strings.Join(names, ", "))
+	for _, field := range parser_data.MonitorFields {
+		documentation += fmt.Sprintf("- **%s**: %s", field.Name, field.Description)
+		if len(field.Keywords) > 0 {
+			documentation += fmt.Sprintf(" Also accepts: %s", strings.Join(field.KeywordValues(), ", "))
+		}
+		documentation += "\n"
+	}
+	return documentation
+}
@@ -0,0 +1,132 @@
+package hyprls
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+
+	"go.lsp.dev/protocol"
+	"go.lsp.dev/uri"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// initializationOptions are the options clients can give in the initializationOptions of the initialize request
+type initializationOptions struct {
+	// SkipFilesystemChecks disables the diagnostics about paths that don't exist, for configs edited on another machine than the one they run on
+	SkipFilesystemChecks bool `json:"skipFilesystemChecks"`
+	// LogLevel is the minimum level of the server's logs: debug, info, warn or error. Defaults to info.
+	LogLevel string `json:"logLevel"`
+	// LogFile is a file the server's logs are written to instead of where they usually go, e.g. to keep the standard error clean
+	LogFile string `json:"logFile"`
+	// EnableTypeChecking enables the diagnostics about values that don't fit their variable, such as out of range numbers or negative sizes
+	EnableTypeChecking bool `json:"enableTypeChecking"`
+	// EnableEnumValidation enables the diagnostics about values that are not one of the values their variable accepts
+	EnableEnumValidation bool `json:"enableEnumValidation"`
+	// HyprlandConfigPath is the path of the main configuration file, see mainConfigFile
+	HyprlandConfigPath string `json:"hyprlandConfigPath"`
+	// HyprctlPath is the hyprctl executable used to get information from the running Hyprland, looked up in the PATH if empty
+	HyprctlPath string `json:"hyprctlPath"`
+}
+
+// defaultOptions are the initialization options used for the fields clients don't give
+var defaultOptions = initializationOptions{
+	LogLevel:             "info",
+	EnableTypeChecking:   true,
+	EnableEnumValidation: true,
+}
+
+// options are the initialization options of the client being served, see defaultOptions
+var options = defaultOptions
+
+// mainConfigFile returns the URI of the configuration file Hyprland loads, which may source the files being edited.
+// It defaults to $XDG_CONFIG_HOME/hypr/hyprland.conf, and can be changed with the hyprlandConfigPath option.
+func mainConfigFile() protocol.URI {
+	if options.HyprlandConfigPath != "" {
+		return uri.File(options.HyprlandConfigPath)
+	}
+
+	configDirectory, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return uri.File(filepath.Join(configDirectory, "hypr", "hyprland.conf"))
+}
+
+// hyprctlExecutable returns the hyprctl executable to run, see initializationOptions.HyprctlPath
+func hyprctlExecutable() string {
+	if options.HyprctlPath != "" {
+		return options.HyprctlPath
+	}
+	return "hyprctl"
+}
+
+// logLevel is the minimum level of the loggers built with NewLogger, see initializationOptions.LogLevel
+var logLevel = zap.NewAtomicLevelAt(zapcore.InfoLevel)
+
+// logOutput is where the loggers built with NewLogger write, see initializationOptions.LogFile
+var logOutput = &switchableOutput{}
+
+// switchableOutput writes to an output that can be changed after loggers using it are built
+type switchableOutput struct {
+	mutex  sync.Mutex
+	output zapcore.WriteSyncer
+	// initial is the output the loggers were built with, used again when the logFile option is not set
+	initial zapcore.WriteSyncer
+}
+
+func (o *switchableOutput) Write(p []byte) (int, error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	return o.output.Write(p)
+}
+
+func (o *switchableOutput) Sync() error {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	return o.output.Sync()
+}
+
+func (o *switchableOutput) set(output zapcore.WriteSyncer) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+	o.output = output
+}
+
+// NewLogger returns a development logger writing to the given paths (see zap.Open), whose level and output follow the logLevel and logFile options.
+// Loggers given to StartServer and ListenAndServe should be built with it, so that the options also apply to the logs of the protocol's messages.
+func NewLogger(paths ...string) (*zap.Logger, error) {
+	output, _, err := zap.Open(paths...)
+	if err != nil {
+		return nil, err
+	}
+	logOutput.initial = output
+	logOutput.set(output)
+
+	core := zapcore.NewCore(zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig()), logOutput, logLevel)
+	return zap.New(core, zap.Development(), zap.AddCaller(), zap.AddStacktrace(zapcore.WarnLevel)), nil
+}
+
+// configureLogging applies the logLevel and logFile options to the loggers built with NewLogger
+func configureLogging() {
+	level, err := zapcore.ParseLevel(options.LogLevel)
+	if err != nil {
+		logger.Warn("invalid log level in initialization options", zap.String("logLevel", options.LogLevel), zap.Error(err))
+		level = zapcore.InfoLevel
+	}
+	logLevel.SetLevel(level)
+
+	if options.LogFile == "" {
+		if logOutput.initial != nil {
+			logOutput.set(logOutput.initial)
+		}
+		return
+	}
+
+	output, _, err := zap.Open(options.LogFile)
+	if err != nil {
+		logger.Warn("while opening the log file given in initialization options", zap.String("logFile", options.LogFile), zap.Error(err))
+		return
+	}
+	logOutput.set(output)
+}
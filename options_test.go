@@ -0,0 +1,34 @@
+package hyprls
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConfigureLogging(t *testing.T) {
+	directory := t.TempDir()
+	startup, configured := filepath.Join(directory, "startup.log"), filepath.Join(directory, "configured.log")
+
+	base, err := NewLogger(startup)
+	if err != nil {
+		t.Fatalf("while building logger: %s", err)
+	}
+	dispatcherLogger := base.Named("dispatcher")
+	originalLogger, originalOptions := logger, options
+	logger = base
+	defer func() { logger, options = originalLogger, originalOptions; configureLogging() }()
+
+	dispatcherLogger.Debug("before initialization")
+	options.LogLevel, options.LogFile = "debug", configured
+	configureLogging()
+	dispatcherLogger.Debug("after initialization")
+
+	if contents, _ := os.ReadFile(startup); len(contents) != 0 {
+		t.Errorf("expected debug logs to be left out by default, got %q", contents)
+	}
+	if contents, _ := os.ReadFile(configured); !strings.Contains(string(contents), "after initialization") {
+		t.Errorf("expected the options to apply to loggers built before them, got %q", contents)
+	}
+}
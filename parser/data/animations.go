@@ -0,0 +1,102 @@
+package parser_data
+
+import (
+	"regexp"
+	"strings"
+)
+
+type AnimationDefinition struct {
+	Name        string
+	Description string
+	// Parent is the name of the animation this one inherits its values from, empty for the root of the tree
+	Parent string
+	// Styles are the values accepted by the STYLE argument. Animations that don't document any take their parent's.
+	Styles []string
+}
+
+// Animations are the animations that can be configured with animation = NAME, ONOFF, SPEED, CURVE[, STYLE], see EnsureLoaded
+var Animations = []AnimationDefinition{}
+
+var parentheticalPattern = regexp.MustCompile(`\s*\(.*?\)`)
+
+// parseAnimations reads the animation tree, documented as an indented list of
+// "↳ name - description - styles: a, b" lines in a code block starting with "global"
+func parseAnimations(source []byte) ([]AnimationDefinition, error) {
+	document, err := markdownToHTML(source)
+	if err != nil {
+		return nil, err
+	}
+
+	animations := make([]AnimationDefinition, 0)
+	for _, code := range document.FindAll("code") {
+		if !strings.HasPrefix(code.FullText(), "global") {
+			continue
+		}
+
+		// ancestors[i] is the name of the last animation seen with an indentation of i
+		ancestors := make(map[int]string)
+		for _, line := range strings.Split(code.FullText(), "\n") {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+
+			indentation := len(line) - len(strings.TrimLeft(line, " "))
+			parts := strings.Split(strings.TrimPrefix(strings.TrimSpace(line), "↳ "), " - ")
+			animation := AnimationDefinition{Name: parts[0]}
+			for i := indentation - 1; i >= 0; i-- {
+				if parent, ok := ancestors[i]; ok {
+					animation.Parent = parent
+					break
+				}
+			}
+			ancestors[indentation] = animation.Name
+			for deeper := range ancestors {
+				if deeper > indentation {
+					delete(ancestors, deeper)
+				}
+			}
+
+			for _, part := range parts[1:] {
+				styles, isStyles := strings.CutPrefix(part, "styles: ")
+				if !isStyles {
+					animation.Description = part
+					continue
+				}
+
+				if other, ok := strings.CutPrefix(styles, "same as "); ok {
+					animation.Styles = findAnimationIn(animations, other).Styles
+					continue
+				}
+
+				for _, style := range strings.Split(parentheticalPattern.ReplaceAllString(styles, ""), ",") {
+					animation.Styles = append(animation.Styles, strings.TrimSpace(style))
+				}
+			}
+
+			if len(animation.Styles) == 0 && animation.Parent != "" {
+				animation.Styles = findAnimationIn(animations, animation.Parent).Styles
+			}
+			animations = append(animations, animation)
+		}
+	}
+	return animations, nil
+}
+
+func findAnimationIn(animations []AnimationDefinition, name string) AnimationDefinition {
+	for _, a := range animations {
+		if a.Name == name {
+			return a
+		}
+	}
+	return AnimationDefinition{}
+}
+
+func FindAnimation(name string) (AnimationDefinition, bool) {
+	EnsureLoaded()
+	for _, a := range Animations {
+		if a.Name == name {
+			return a, true
+		}
+	}
+	return AnimationDefinition{}, false
+}
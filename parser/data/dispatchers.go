@@ -0,0 +1,88 @@
+package parser_data
+
+type DispatcherDefinition struct {
+	Name        string
+	Description string
+	Params      string
+}
+
+// Dispatchers are the built-in dispatchers that can be used in binds, see EnsureLoaded
+var Dispatchers = []DispatcherDefinition{}
+
+// MouseDispatchers are the only dispatchers that can be used in mouse binds (bindm), see EnsureLoaded
+var MouseDispatchers = []DispatcherDefinition{}
+
+func parseDispatchers(source []byte) ([]DispatcherDefinition, error) {
+	return parseDispatchersTables(source, []string{"dispatcher", "description", "params"})
+}
+
+// parseMouseDispatchers reads the table of dispatchers available to mouse binds
+func parseMouseDispatchers(source []byte) ([]DispatcherDefinition, error) {
+	return parseDispatchersTables(source, []string{"name", "description", "params"})
+}
+
+// parseDispatchersTables reads the dispatchers of the tables with the given lowercased header cells
+func parseDispatchersTables(source []byte, header []string) ([]DispatcherDefinition, error) {
+	document, err := markdownToHTML(source)
+	if err != nil {
+		return nil, err
+	}
+
+	dispatchers := make([]DispatcherDefinition, 0)
+	for _, table := range document.FindAll("table") {
+		if !arraysEqual(lowercased(tableHeaderCells(table)), header) {
+			continue
+		}
+
+		for _, row := range table.FindAll("tr")[1:] {
+			cells := row.FindAll("td")
+			if len(cells) != 3 {
+				continue
+			}
+
+			dispatchers = append(dispatchers, DispatcherDefinition{
+				Name:        cells[0].FullText(),
+				Description: cells[1].FullText(),
+				Params:      cells[2].FullText(),
+			})
+		}
+	}
+	return dispatchers, nil
+}
+
+func FindDispatcher(name string) (DispatcherDefinition, bool) {
+	EnsureLoaded()
+	for _, d := range Dispatchers {
+		if d.Name == name {
+			return d, true
+		}
+	}
+	return DispatcherDefinition{}, false
+}
+
+type WorkspaceSelector struct {
+	// Value is an example of the selector, e.g. m+1
+	Value       string
+	Description string
+}
+
+// WorkspaceSelectors are the ways dispatchers taking a workspace can refer to one.
+// See https://wiki.hyprland.org/Configuring/Dispatchers/#workspaces
+var WorkspaceSelectors = []WorkspaceSelector{
+	{Value: "+1", Description: "Next workspace, relative to the current one"},
+	{Value: "-1", Description: "Previous workspace, relative to the current one"},
+	{Value: "m+1", Description: "Next workspace on the current monitor"},
+	{Value: "m-1", Description: "Previous workspace on the current monitor"},
+	{Value: "m~1", Description: "First workspace on the current monitor (absolute)"},
+	{Value: "r+1", Description: "Next workspace on the current monitor, including empty workspaces"},
+	{Value: "r-1", Description: "Previous workspace on the current monitor, including empty workspaces"},
+	{Value: "r~1", Description: "First workspace on the current monitor, including empty workspaces (absolute)"},
+	{Value: "e+1", Description: "Next open workspace"},
+	{Value: "e-1", Description: "Previous open workspace"},
+	{Value: "e~1", Description: "First open workspace (absolute)"},
+	{Value: "name:", Description: "Workspace with the given name"},
+	{Value: "previous", Description: "Previous workspace"},
+	{Value: "empty", Description: "First available empty workspace"},
+	{Value: "special", Description: "Special workspace, only supported by movetoworkspace and movetoworkspacesilent"},
+	{Value: "special:", Description: "Named special workspace, only supported by movetoworkspace and movetoworkspacesilent"},
+}
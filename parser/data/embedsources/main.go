@@ -0,0 +1,121 @@
+// Command embedsources writes the go:embed directives of the wiki pages parser_data is parsed from, to the file given as argument.
+// The pages are looked up by name in the sources directory, so that a new version of the wiki moving them around does not require editing the directives by hand.
+// Run it with go generate.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"os"
+	"path"
+	"slices"
+	"strings"
+)
+
+// embeddedPages are the wiki pages that are embedded as byte slices, by variable name
+var embeddedPages = []struct {
+	variable string
+	page     string
+}{
+	{"documentationSource", "Variables"},
+	{"masterLayoutDocumentationSource", "Master-Layout"},
+	{"dwindleLayoutDocumentationSource", "Dwindle-Layout"},
+	{"dispatchersDocumentationSource", "Dispatchers"},
+	{"bindsDocumentationSource", "Binds"},
+	{"windowRulesDocumentationSource", "Window-Rules"},
+	{"workspaceRulesDocumentationSource", "Workspace-Rules"},
+	{"animationsDocumentationSource", "Animations"},
+}
+
+func main() {
+	if len(os.Args) != 3 {
+		fmt.Fprintln(os.Stderr, "Usage: embedsources SOURCES_DIRECTORY OUTPUT")
+		os.Exit(2)
+	}
+
+	pages, directories, err := findPages(os.Args[1])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "while looking for pages in %s: %s\n", os.Args[1], err)
+		os.Exit(1)
+	}
+
+	code, err := generate(pages, directories)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "while generating embed directives: %s\n", err)
+		os.Exit(1)
+	}
+
+	if err := os.WriteFile(os.Args[2], code, 0644); err != nil {
+		fmt.Fprintf(os.Stderr, "while writing %s: %s\n", os.Args[2], err)
+		os.Exit(1)
+	}
+}
+
+// findPages maps the name of each markdown page under root to its path, and lists the directories containing pages.
+// Pages whose name is not unique (e.g. _index) are left out of the map.
+func findPages(root string) (pages map[string]string, directories []string, err error) {
+	pages = make(map[string]string)
+	duplicates := make(map[string]bool)
+	err = fs.WalkDir(os.DirFS("."), root, func(filepath string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || path.Ext(filepath) != ".md" {
+			return err
+		}
+
+		if !slices.Contains(directories, path.Dir(filepath)) {
+			directories = append(directories, path.Dir(filepath))
+		}
+
+		name := strings.TrimSuffix(path.Base(filepath), ".md")
+		if _, ok := pages[name]; ok || duplicates[name] {
+			delete(pages, name)
+			duplicates[name] = true
+			return nil
+		}
+		pages[name] = filepath
+		return nil
+	})
+	return pages, directories, err
+}
+
+func generate(pages map[string]string, directories []string) ([]byte, error) {
+	var code bytes.Buffer
+	fmt.Fprintln(&code, "// Code generated by go run ./embedsources; DO NOT EDIT.")
+	fmt.Fprintln(&code)
+	fmt.Fprintln(&code, "package parser_data")
+	fmt.Fprintln(&code)
+	fmt.Fprintln(&code, `import "embed"`)
+
+	for _, embedded := range embeddedPages {
+		filepath, ok := pages[embedded.page]
+		if !ok {
+			return nil, fmt.Errorf("page %s not found", embedded.page)
+		}
+		fmt.Fprintln(&code)
+		fmt.Fprintf(&code, "//go:embed %s\n", filepath)
+		fmt.Fprintf(&code, "var %s []byte\n", embedded.variable)
+	}
+
+	fmt.Fprintln(&code)
+	for _, directory := range directories {
+		fmt.Fprintf(&code, "//go:embed %s/*.md\n", directory)
+	}
+	fmt.Fprintln(&code, "var documentationSources embed.FS")
+
+	names := make([]string, 0, len(pages))
+	for name := range pages {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	fmt.Fprintln(&code)
+	fmt.Fprintln(&code, "// documentationSourcePaths maps the name of wiki pages to their path in documentationSources")
+	fmt.Fprintln(&code, "var documentationSourcePaths = map[string]string{")
+	for _, name := range names {
+		fmt.Fprintf(&code, "\t%q: %q,\n", name, pages[name])
+	}
+	fmt.Fprintln(&code, "}")
+
+	return format.Source(code.Bytes())
+}
@@ -0,0 +1,29 @@
+// Command encode parses the documentation and writes it to the file given as argument, to be embedded in parser_data.
+// Run it with go generate.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	parser_data "github.com/ewen-lbh/hyprls/parser/data"
+)
+
+func main() {
+	if len(os.Args) != 2 {
+		fmt.Fprintln(os.Stderr, "Usage: encode OUTPUT")
+		os.Exit(2)
+	}
+
+	out, err := os.Create(os.Args[1])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "while creating %s: %s\n", os.Args[1], err)
+		os.Exit(1)
+	}
+	defer out.Close()
+
+	if err := parser_data.EncodeDocumentation(out); err != nil {
+		fmt.Fprintf(os.Stderr, "while encoding documentation: %s\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,128 @@
+package parser_data
+
+import (
+	"bytes"
+	"crypto/sha256"
+	_ "embed"
+	"encoding/gob"
+	"errors"
+	"io"
+	"io/fs"
+)
+
+//go:generate go run ./embedsources sources sources_generated.go
+//go:generate go run ./encode documentation.gob
+
+// encodedDocumentation is the documentation, parsed at build time by go generate, see EncodeDocumentation
+//
+//go:embed documentation.gob
+var encodedDocumentation []byte
+
+type documentation struct {
+	// SourcesHash identifies the markdown sources the documentation was parsed from, to detect when it needs to be generated again
+	SourcesHash         []byte
+	Sections            []SectionDefinition
+	KeywordDescriptions []keywordDescription
+	Dispatchers         []DispatcherDefinition
+	MouseDispatchers    []DispatcherDefinition
+	WorkspaceRules      []WorkspaceRuleDefinition
+	Animations          []AnimationDefinition
+	WindowRules         []WindowRuleDefinition
+	LayerRules          []RuleDefinition
+}
+
+type keywordDescription struct {
+	Name        string
+	Description string
+}
+
+// LoadFromSources parses the markdown sources of the documentation, instead of decoding the pre-parsed one like EnsureLoaded.
+// It must be called before anything else from this package, otherwise it does nothing.
+func LoadFromSources() {
+	loadOnce.Do(func() {
+		parseDocumentation()
+		indexVariables(Sections)
+		indexKeywords()
+	})
+}
+
+// EncodeDocumentation parses the markdown sources of the documentation and writes the result to w, to be embedded at build time
+func EncodeDocumentation(w io.Writer) error {
+	LoadFromSources()
+	return encodeDocumentation(w)
+}
+
+// encodeDocumentation writes the currently loaded documentation to w
+func encodeDocumentation(w io.Writer) error {
+	hash, err := sourcesHash()
+	if err != nil {
+		return err
+	}
+
+	// Not a map, so that encoding the same documentation always gives the same bytes
+	keywordDescriptions := make([]keywordDescription, 0, len(Keywords))
+	for _, k := range Keywords {
+		keywordDescriptions = append(keywordDescriptions, keywordDescription{Name: k.Name, Description: k.Description})
+	}
+
+	return gob.NewEncoder(w).Encode(documentation{
+		SourcesHash:         hash,
+		Sections:            Sections,
+		KeywordDescriptions: keywordDescriptions,
+		Dispatchers:         Dispatchers,
+		MouseDispatchers:    MouseDispatchers,
+		WorkspaceRules:      WorkspaceRules,
+		Animations:          Animations,
+		WindowRules:         WindowRules,
+		LayerRules:          LayerRules,
+	})
+}
+
+func decodeDocumentation(encoded []byte) error {
+	var decoded documentation
+	if err := gob.NewDecoder(bytes.NewReader(encoded)).Decode(&decoded); err != nil {
+		return err
+	}
+
+	hash, err := sourcesHash()
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(hash, decoded.SourcesHash) {
+		return errors.New("the documentation sources changed since it was generated, run go generate")
+	}
+
+	Sections = decoded.Sections
+	Dispatchers = decoded.Dispatchers
+	MouseDispatchers = decoded.MouseDispatchers
+	WorkspaceRules = decoded.WorkspaceRules
+	Animations = decoded.Animations
+	WindowRules = decoded.WindowRules
+	LayerRules = decoded.LayerRules
+	for i, k := range Keywords {
+		for _, description := range decoded.KeywordDescriptions {
+			if description.Name == k.Name {
+				Keywords[i].Description = description.Description
+			}
+		}
+	}
+	return nil
+}
+
+// sourcesHash hashes the markdown sources of the documentation
+func sourcesHash() ([]byte, error) {
+	hash := sha256.New()
+	err := fs.WalkDir(documentationSources, "sources", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		contents, err := documentationSources.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		hash.Write([]byte(path))
+		hash.Write(contents)
+		return nil
+	})
+	return hash.Sum(nil), err
+}
@@ -0,0 +1,22 @@
+package parser_data
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestEncodedDocumentationIsUpToDate fails when the parsing code changed but documentation.gob was not generated again.
+// Changes to the sources themselves are detected at runtime, see decodeDocumentation.
+func TestEncodedDocumentationIsUpToDate(t *testing.T) {
+	parseDocumentation()
+	defer indexVariables(Sections)
+
+	var encoded bytes.Buffer
+	if err := encodeDocumentation(&encoded); err != nil {
+		t.Fatalf("while encoding documentation: %s", err)
+	}
+
+	if !bytes.Equal(encoded.Bytes(), encodedDocumentation) {
+		t.Fatal("documentation.gob is outdated, run go generate ./parser/data")
+	}
+}
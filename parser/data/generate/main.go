@@ -10,8 +10,9 @@ import (
 )
 
 func main() {
+	LoadFromSources()
 	rootSections := make([]SectionDefinition, 0)
-	for _, section := range Sections {
+	for _, section := range GetSections() {
 		if len(section.Path) == 1 {
 			rootSections = append(rootSections, section)
 		}
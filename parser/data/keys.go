@@ -0,0 +1,38 @@
+package parser_data
+
+// ModifierCombinations are commonly used combinations of modifiers, proposed alongside single modifiers in binds
+var ModifierCombinations = []string{
+	"SUPER_SHIFT",
+	"SUPER_CTRL",
+	"SUPER_ALT",
+	"SUPER_CTRL_SHIFT",
+	"SUPER_ALT_SHIFT",
+	"CTRL_SHIFT",
+	"CTRL_ALT",
+	"ALT_SHIFT",
+}
+
+// KeyNames are the names of commonly bound keys, as xkb keysyms
+var KeyNames = []string{
+	// Letters and digits
+	"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k", "l", "m",
+	"n", "o", "p", "q", "r", "s", "t", "u", "v", "w", "x", "y", "z",
+	"0", "1", "2", "3", "4", "5", "6", "7", "8", "9",
+	// Function keys
+	"F1", "F2", "F3", "F4", "F5", "F6", "F7", "F8", "F9", "F10", "F11", "F12",
+	// Editing and navigation
+	"Return", "space", "Tab", "Escape", "BackSpace", "Delete", "Insert",
+	"Home", "End", "Page_Up", "Page_Down", "Left", "Right", "Up", "Down",
+	"Print", "Pause", "Menu", "Caps_Lock", "Scroll_Lock", "Num_Lock",
+	// Punctuation
+	"comma", "period", "slash", "backslash", "semicolon", "apostrophe", "grave",
+	"minus", "equal", "plus", "bracketleft", "bracketright",
+	// Media keys
+	"XF86AudioRaiseVolume", "XF86AudioLowerVolume", "XF86AudioMute", "XF86AudioMicMute",
+	"XF86AudioPlay", "XF86AudioPause", "XF86AudioStop", "XF86AudioNext", "XF86AudioPrev",
+	"XF86MonBrightnessUp", "XF86MonBrightnessDown", "XF86Calculator", "XF86Search",
+	"XF86PowerOff", "XF86Sleep",
+	// Mouse, see https://wiki.hyprland.org/Configuring/Binds/#mouse-buttons
+	"mouse:272", "mouse:273", "mouse:274", "mouse:275", "mouse:276",
+	"mouse_down", "mouse_up", "mouse_left", "mouse_right",
+}
@@ -11,6 +11,13 @@ type KeywordDefinition struct {
 	documentationHeadingSlug string
 	documentationFile        string
 	Flags                    []string
+	// Parameters are the comma-separated arguments the keyword takes, in order. The last one can contain commas itself.
+	Parameters []KeywordParameter
+}
+
+type KeywordParameter struct {
+	Name        string
+	Description string
 }
 
 func (k KeywordDefinition) DocumentationLink() string {
@@ -29,12 +36,20 @@ var Keywords = []KeywordDefinition{
 		documentationHeadingSlug: "window-rules-v1",
 		documentationFile:        "Window-Rules",
 		Flags:                    []string{},
+		Parameters: []KeywordParameter{
+			{Name: "rule", Description: "The rule to apply, e.g. float or opacity 0.8"},
+			{Name: "window", Description: "A regular expression matched against the class of the window"},
+		},
 	},
 	{
 		Name:                     "windowrulev2",
 		documentationHeadingSlug: "window-rules-v2",
 		documentationFile:        "Window-Rules",
 		Flags:                    []string{},
+		Parameters: []KeywordParameter{
+			{Name: "rule", Description: "The rule to apply, e.g. float or opacity 0.8"},
+			{Name: "window", Description: "Comma-separated filters the window must match, e.g. class:^(kitty)$, title:^(.*vim.*)$"},
+		},
 	},
 	{
 		Name:                     "layerrule",
@@ -53,12 +68,26 @@ var Keywords = []KeywordDefinition{
 		documentationHeadingSlug: "general",
 		documentationFile:        "Animations",
 		Flags:                    []string{},
+		Parameters: []KeywordParameter{
+			{Name: "name", Description: "The animation to configure, e.g. windows or workspaces"},
+			{Name: "onoff", Description: "1 to enable the animation, 0 to disable it"},
+			{Name: "speed", Description: "Duration of the animation, in ds (1ds = 100ms)"},
+			{Name: "curve", Description: "Name of the bezier curve to use, e.g. default"},
+			{Name: "style", Description: "Optional style of the animation, e.g. slide or popin 80%"},
+		},
 	},
 	{
 		Name:                     "bezier",
 		documentationHeadingSlug: "curves",
 		documentationFile:        "Animations",
 		Flags:                    []string{},
+		Parameters: []KeywordParameter{
+			{Name: "name", Description: "Name of the curve, to use in animations"},
+			{Name: "x0", Description: "X coordinate of the first control point"},
+			{Name: "y0", Description: "Y coordinate of the first control point"},
+			{Name: "x1", Description: "X coordinate of the second control point"},
+			{Name: "y1", Description: "Y coordinate of the second control point"},
+		},
 	},
 	{
 		Name:                     "exec",
@@ -89,12 +118,24 @@ var Keywords = []KeywordDefinition{
 		documentationHeadingSlug: "general",
 		documentationFile:        "Monitors",
 		Flags:                    []string{},
+		Parameters: []KeywordParameter{
+			{Name: "name", Description: "Name of the monitor, e.g. DP-1, desc: followed by its description, or empty for any monitor"},
+			{Name: "resolution", Description: "Resolution and refresh rate, e.g. 1920x1080@144, or preferred, highres or highrr"},
+			{Name: "position", Description: "Position of the monitor in the layout, e.g. 0x0, or auto"},
+			{Name: "scale", Description: "Scaling factor, e.g. 1.5, or auto"},
+		},
 	},
 	{
 		Name:                     "bind",
 		documentationHeadingSlug: "basic",
 		documentationFile:        "Binds",
 		Flags:                    []string{"r", "l", "e", "n", "m", "t", "i"},
+		Parameters: []KeywordParameter{
+			{Name: "mods", Description: "Modifier keys to hold, e.g. SUPER SHIFT, or empty for none"},
+			{Name: "key", Description: "Key to press, e.g. Q, Return or code:24"},
+			{Name: "dispatcher", Description: "Dispatcher to call, e.g. exec or killactive"},
+			{Name: "args", Description: "Arguments of the dispatcher, if it takes any"},
+		},
 	},
 	{
 		Name:                     "unbind",
@@ -104,7 +145,23 @@ var Keywords = []KeywordDefinition{
 	},
 }
 
+// KeywordsByName indexes Keywords by name. It is filled once the keywords' descriptions are loaded, see EnsureLoaded.
+var KeywordsByName = map[string]KeywordDefinition{}
+
+func indexKeywords() {
+	KeywordsByName = make(map[string]KeywordDefinition, len(Keywords))
+	for _, k := range Keywords {
+		KeywordsByName[k.Name] = k
+	}
+}
+
+// FindKeyword returns the keyword used by key, which can be the keyword's name followed by some of its flags, e.g. binde for bind
 func FindKeyword(key string) (keyword KeywordDefinition, found bool) {
+	EnsureLoaded()
+	if k, ok := KeywordsByName[key]; ok {
+		return k, true
+	}
+
 	for _, k := range Keywords {
 		if key == k.Name {
 			return k, true
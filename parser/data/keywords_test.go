@@ -20,3 +20,20 @@ func TestFindKeyword(t *testing.T) {
 	}
 
 }
+
+func TestKeywordsByName(t *testing.T) {
+	EnsureLoaded()
+	for _, k := range Keywords {
+		indexed, found := KeywordsByName[k.Name]
+		if !found {
+			t.Fatalf("%s is not in KeywordsByName", k.Name)
+		}
+		if indexed.Description != k.Description {
+			t.Fatalf("%s in KeywordsByName has a different description than in Keywords", k.Name)
+		}
+	}
+
+	if k, found := FindKeyword("binde"); !found || k.Name != "bind" {
+		t.Fatalf("expected binde to be found as bind, got %+v (found=%v)", k, found)
+	}
+}
@@ -2,14 +2,17 @@ package parser_data
 
 import (
 	"bytes"
-	"embed"
-	_ "embed"
+	"errors"
 	"fmt"
+	"html"
 	"os"
-	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/PuerkitoBio/goquery"
 	"github.com/anaskhan96/soup"
@@ -20,27 +23,36 @@ import (
 	html2markdown "github.com/evorts/html-to-markdown"
 )
 
-var html2md = html2markdown.NewConverter("wiki.hyprlang.org", true, &html2markdown.Options{})
+var html2md = newHTML2MDConverter()
+
+func newHTML2MDConverter() *html2markdown.Converter {
+	converter := html2markdown.NewConverter("wiki.hyprlang.org", true, &html2markdown.Options{})
+	converter.AddRules(html2markdown.Rule{
+		Filter: []string{"a"},
+		Replacement: func(content string, selec *goquery.Selection, options *html2markdown.Options) *string {
+			href, _ := selec.Attr("href")
+			if strings.HasPrefix(href, "../") {
+				href = strings.Replace(href, "../", "https://wiki.hyprland.org/Configuring/", 1)
+			}
+			result := fmt.Sprintf("[%s](%s)", content, href)
+			return html2markdown.String(result)
+		},
+	})
+	return converter
+}
+
 var md = goldmark.New(goldmark.WithExtensions(extension.GFM))
 
 func debug(msg string, fmtArgs ...any) {
 	// fmt.Fprintf(os.Stderr, msg, fmtArgs...)
 }
 
-//go:embed sources/Variables.md
-var documentationSource []byte
-
-//go:embed sources/Master-Layout.md
-var masterLayoutDocumentationSource []byte
-
-//go:embed sources/Dwindle-Layout.md
-var dwindleLayoutDocumentationSource []byte
-
-//go:embed sources/*.md
-var documentationSources embed.FS
-
+// Sections are the sections documented in the wiki, see EnsureLoaded
+//
+// Deprecated: use GetSections, which can't be modified by accident, or LoadedSections to only read them.
 var Sections = []SectionDefinition{}
 
+// undocumentedGeneralSectionVariables are set at the root of configuration files, which is parsed as the General section
 var undocumentedGeneralSectionVariables = []VariableDefinition{
 	{
 		Name:        "autogenerated",
@@ -50,45 +62,260 @@ var undocumentedGeneralSectionVariables = []VariableDefinition{
 	},
 }
 
-func (s SectionDefinition) VariableDefinition(name string) *VariableDefinition {
-	for _, v := range s.Variables {
-		if v.Name == name {
-			return &v
+// IsRootVariable returns true if the variable is set at the root of configuration files, outside of any section, e.g. autogenerated
+func IsRootVariable(name string) bool {
+	for _, variable := range undocumentedGeneralSectionVariables {
+		if variable.Name == name {
+			return true
 		}
 	}
-	return nil
+	return false
 }
 
-func init() {
-	html2md.AddRules(html2markdown.Rule{
-		Filter: []string{"a"},
-		Replacement: func(content string, selec *goquery.Selection, options *html2markdown.Options) *string {
-			href, _ := selec.Attr("href")
-			if strings.HasPrefix(href, "../") {
-				href = strings.Replace(href, "../", "https://wiki.hyprland.org/Configuring/", 1)
-			}
-			result := fmt.Sprintf("[%s](%s)", content, href)
-			return html2markdown.String(result)
+// deprecatedVariables are variables that were removed or moved to another section, by section name
+var deprecatedVariables = map[string][]VariableDefinition{
+	"General": {
+		deprecatedVariable("col.group_border", "color", "group:col.border_inactive"),
+		deprecatedVariable("col.group_border_active", "color", "group:col.border_active"),
+		deprecatedVariable("col.group_border_locked", "color", "group:col.border_locked_inactive"),
+		deprecatedVariable("col.group_border_locked_active", "color", "group:col.border_locked_active"),
+	},
+	"Decoration": {
+		deprecatedVariable("blur", "bool", "decoration:blur:enabled"),
+		deprecatedVariable("blur_size", "int", "decoration:blur:size"),
+		deprecatedVariable("blur_passes", "int", "decoration:blur:passes"),
+		deprecatedVariable("blur_ignore_opacity", "bool", "decoration:blur:ignore_opacity"),
+		deprecatedVariable("blur_new_optimizations", "bool", "decoration:blur:new_optimizations"),
+		deprecatedVariable("blur_xray", "bool", "decoration:blur:xray"),
+		deprecatedVariable("multisample_edges", "bool", ""),
+	},
+	"Misc": {
+		deprecatedVariable("groupbar_gradients", "bool", "group:groupbar:gradients"),
+		deprecatedVariable("groupbar_titles_font_size", "int", "group:groupbar:font_size"),
+		deprecatedVariable("groupbar_text_color", "color", "group:groupbar:text_color"),
+		deprecatedVariable("render_titles_in_groupbar", "bool", "group:groupbar:render_titles"),
+		deprecatedVariable("group_insert_after_current", "bool", "group:insert_after_current"),
+		deprecatedVariable("group_focus_removed_window", "bool", "group:focus_removed_window"),
+	},
+}
+
+// valueSuggestions are noteworthy values of some variables, by section name and variable name
+var valueSuggestions = map[string]map[string][]ValueSuggestion{
+	"General": {
+		"layout": {
+			{Value: "dwindle", Label: "Dwindle tiling layout"},
+			{Value: "master", Label: "Master tiling layout"},
+		},
+	},
+	"Decoration": {
+		"active_opacity":   opacitySuggestions,
+		"inactive_opacity": opacitySuggestions,
+		"dim_inactive": {
+			{Value: "true", Label: "dim inactive windows, by dim_strength"},
+			{Value: "false", Label: "default, inactive windows keep their brightness"},
 		},
+	},
+	"Input": {
+		"sensitivity": {
+			{Value: "-1.0", Label: "slowest"},
+			{Value: "0.0", Label: "default, no acceleration"},
+			{Value: "1.0", Label: "fastest"},
+		},
+	},
+}
+
+var opacitySuggestions = []ValueSuggestion{
+	{Value: "0.0", Label: "fully transparent"},
+	{Value: "0.5", Label: "semi-transparent"},
+	{Value: "0.85", Label: "mostly opaque"},
+	{Value: "0.9", Label: "mostly opaque"},
+	{Value: "0.95", Label: "almost opaque"},
+	{Value: "1.0", Label: "default (opaque)"},
+}
+
+func addValueSuggestions(sectionName, variableName string, suggestions []ValueSuggestion) {
+	for i, sec := range Sections {
+		if sec.Name() != sectionName {
+			continue
+		}
+		for j, v := range sec.Variables {
+			if v.Name == variableName {
+				Sections[i].Variables[j].Suggestions = suggestions
+			}
+		}
+	}
+}
+
+// misplacedSectionPaths are the sections the documentation places under the wrong heading, by documented path, with their path in configuration files
+var misplacedSectionPaths = map[string][]string{
+	"Custom accel profiles:Touchpad":    {"Input", "Touchpad"},
+	"Custom accel profiles:Touchdevice": {"Input", "Touchdevice"},
+	"Custom accel profiles:Tablet":      {"Input", "Tablet"},
+}
+
+// deprecatedSections are sections that were removed or moved as a whole, by section name, with a message explaining what to use instead.
+// None are at the moment: the documented deprecations are of variables, see deprecatedVariables.
+var deprecatedSections = map[string]string{}
+
+// markDeprecatedSections flags the sections listed in deprecated, and their subsections with the same name, as deprecated
+func markDeprecatedSections(sections []SectionDefinition, deprecated map[string]string) {
+	for i, sec := range sections {
+		if message, ok := deprecated[sec.Name()]; ok {
+			sections[i].Deprecated = true
+			sections[i].DeprecatedMessage = message
+		}
+		markDeprecatedSections(sections[i].Subsections, deprecated)
+	}
+}
+
+func deprecatedVariable(name, typ, replacedWith string) VariableDefinition {
+	description := "Deprecated, this variable was removed."
+	if replacedWith != "" {
+		description = fmt.Sprintf("Deprecated, use `%s` instead.", replacedWith)
+	}
+	return VariableDefinition{
+		Name:         name,
+		Description:  description,
+		Type:         typ,
+		Deprecated:   true,
+		ReplacedWith: replacedWith,
+	}
+}
+
+func (s SectionDefinition) VariableDefinition(name string) *VariableDefinition {
+	if s.variables == nil {
+		for _, v := range s.Variables {
+			if v.Name == name {
+				return &v
+			}
+		}
+		return nil
+	}
+
+	s.variables.once.Do(func() {
+		s.variables.byName = make(map[string]int, len(s.Variables))
+		for i, v := range s.Variables {
+			if _, exists := s.variables.byName[v.Name]; !exists {
+				s.variables.byName[v.Name] = i
+			}
+		}
 	})
 
-	Sections = parseDocumentationMarkdown(documentationSource, 3)
-	Sections = append(Sections, parseDocumentationMarkdownWithRootSectionName(masterLayoutDocumentationSource, 2, "Master")...)
-	Sections = append(Sections, parseDocumentationMarkdownWithRootSectionName(dwindleLayoutDocumentationSource, 2, "Dwindle")...)
+	i, found := s.variables.byName[name]
+	if !found {
+		return nil
+	}
+	v := s.Variables[i]
+	return &v
+}
+
+var loadOnce sync.Once
+
+// EnsureLoaded loads the bundled documentation into Sections, Keywords, Dispatchers, MouseDispatchers, WorkspaceRules, Animations, WindowRules and LayerRules.
+// The work is done on the first call only. It must be called before reading these variables directly, the Find* functions call it themselves.
+func EnsureLoaded() {
+	loadOnce.Do(load)
+}
+
+// load decodes the documentation pre-parsed by go generate, and only parses the markdown sources if that fails
+func load() {
+	if err := decodeDocumentation(encodedDocumentation); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to decode the pre-parsed documentation, parsing it instead: %s\n", err)
+		parseDocumentation()
+	}
+	indexVariables(Sections)
+	indexKeywords()
+	versionedVariables = anyVersionedVariable(Sections)
+}
+
+// parseDocumentation parses the markdown sources of the documentation
+func parseDocumentation() {
+	// Malformed parts of the documentation are skipped, the rest is still usable
+	var err error
+	Dispatchers, err = parseDispatchers(dispatchersDocumentationSource)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to parse dispatchers documentation: %s\n", err)
+	}
+	MouseDispatchers, err = parseMouseDispatchers(bindsDocumentationSource)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to parse mouse binds documentation: %s\n", err)
+	}
+	WorkspaceRules, err = parseWorkspaceRules(workspaceRulesDocumentationSource)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to parse workspace rules documentation: %s\n", err)
+	}
+	Animations, err = parseAnimations(animationsDocumentationSource)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to parse animations documentation: %s\n", err)
+	}
+	WindowRules, err = parseWindowRules(windowRulesDocumentationSource)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to parse window rules documentation: %s\n", err)
+	}
+	LayerRules, err = parseLayerRules(windowRulesDocumentationSource)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to parse layer rules documentation: %s\n", err)
+	}
+
+	// The sources are independent, parse them in parallel. Each goroutine only writes to its own slot of parsed and errs.
+	parsed := make([][]SectionDefinition, 3)
+	errs := make([]error, 3)
+	var wg sync.WaitGroup
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		parsed[0], errs[0] = parseDocumentationMarkdown(documentationSource, 3)
+	}()
+	go func() {
+		defer wg.Done()
+		parsed[1], errs[1] = parseDocumentationMarkdownWithRootSectionName(masterLayoutDocumentationSource, 2, "Master")
+	}()
+	go func() {
+		defer wg.Done()
+		parsed[2], errs[2] = parseDocumentationMarkdownWithRootSectionName(dwindleLayoutDocumentationSource, 2, "Dwindle")
+	}()
+	wg.Wait()
+
+	Sections = make([]SectionDefinition, 0)
+	for i, sections := range parsed {
+		if errs[i] != nil {
+			fmt.Fprintf(os.Stderr, "Skipped malformed sections of the documentation: %s\n", errs[i])
+		}
+		Sections = append(Sections, sections...)
+	}
 	addVariableDefsOnSection("General", undocumentedGeneralSectionVariables)
+	for sectionName, variables := range deprecatedVariables {
+		addVariableDefsOnSection(sectionName, variables)
+	}
+	markDeprecatedSections(Sections, deprecatedSections)
+	for sectionName, variables := range valueSuggestions {
+		for variableName, suggestions := range variables {
+			addValueSuggestions(sectionName, variableName, suggestions)
+		}
+	}
 
 	for i, kw := range Keywords {
 		if kw.Description != "" {
 			continue
 		}
 
-		content, err := documentationSources.ReadFile(filepath.Join("sources", kw.documentationFile+".md"))
+		sourcePath, ok := documentationSourcePaths[kw.documentationFile]
+		if !ok {
+			fmt.Fprintf(os.Stderr, "Failed to find documentation file %s for %s\n", kw.documentationFile, kw.Name)
+			continue
+		}
+
+		content, err := documentationSources.ReadFile(sourcePath)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Failed to read documentation file for %s: %s\n", kw.Name, err)
 			continue
 		}
 
-		document := markdownToHTML(content)
+		document, err := markdownToHTML(content)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to parse documentation file for %s: %s\n", kw.Name, err)
+			continue
+		}
 		headings := make([]soup.Root, 0)
 		for _, t := range []string{"h1", "h2", "h3", "h4", "h5", "h6"} {
 			headings = append(headings, document.FindAll(t)...)
@@ -101,9 +328,7 @@ func init() {
 				found = true
 				break
 			}
-			anchor := slugify.Marshal(strings.TrimSpace(h.Text()), true)
-			anchor = regexp.MustCompile(`^weight-%d+-title-`).ReplaceAllString(anchor, "")
-			if anchor == kw.documentationHeadingSlug {
+			if headingSlug(h) == kw.documentationHeadingSlug {
 				heading = h
 				found = true
 				break
@@ -113,7 +338,11 @@ func init() {
 			fmt.Fprintf(os.Stderr, "Failed to find heading %s in %s\n", kw.documentationHeadingSlug, kw.documentationFile)
 			continue
 		}
-		Keywords[i].Description, _ = html2md.ConvertString(htmlBetweenHeadingAndNextHeading(heading, heading))
+		rendered, err := htmlBetweenHeadingAndNextHeading(heading, heading)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "WARN: Failed to render the documentation of %s, its description is incomplete: %s\n", kw.Name, err)
+		}
+		Keywords[i].Description, _ = html2md.ConvertString(rendered)
 	}
 }
 
@@ -126,47 +355,85 @@ func addVariableDefsOnSection(sectionName string, variables []VariableDefinition
 	}
 }
 
-func parseDocumentationMarkdownWithRootSectionName(source []byte, headingRootLevel int, rootSectionName string) []SectionDefinition {
-	sections := parseDocumentationMarkdown(source, headingRootLevel)
+func parseDocumentationMarkdownWithRootSectionName(source []byte, headingRootLevel int, rootSectionName string) ([]SectionDefinition, error) {
+	sections, err := parseDocumentationMarkdown(source, headingRootLevel)
 	for i := range sections {
 		sections[i].Path[0] = rootSectionName
 	}
-	return sections
+	return sections, err
 }
 
-func markdownToHTML(source []byte) soup.Root {
+func markdownToHTML(source []byte) (soup.Root, error) {
 	var html bytes.Buffer
 	err := md.Convert(source, &html)
 	if err != nil {
-		panic(err)
+		return soup.Root{}, fmt.Errorf("while converting markdown to HTML: %w", err)
 	}
 
-	return soup.HTMLParse(html.String())
+	return soup.HTMLParse(html.String()), nil
 }
 
-func parseDocumentationMarkdown(source []byte, headingRootLevel int) (sections []SectionDefinition) {
-	document := markdownToHTML(source)
+// parseDocumentationMarkdown returns the sections documented by the variables tables of source.
+// Tables whose section can't be determined are skipped, and reported in the returned error along with the sections that could be parsed.
+func parseDocumentationMarkdown(source []byte, headingRootLevel int) (sections []SectionDefinition, err error) {
+	document, err := markdownToHTML(source)
+	if err != nil {
+		return nil, err
+	}
+
+	var skipped []error
 	for _, table := range document.FindAll("table") {
 		if !arraysEqual(tableHeaderCells(table), []string{"name", "description", "type", "default"}) {
 			continue
 		}
 
 		// fmt.Printf("Processing table %s\n", table.HTML())
+		path, err := tablePath(table, headingRootLevel)
+		if errors.Is(err, errNoHeading) {
+			continue
+		}
+		if err != nil {
+			skipped = append(skipped, err)
+			continue
+		}
+		if actual, ok := misplacedSectionPaths[strings.Join(path, ":")]; ok {
+			path = slices.Clone(actual)
+		}
 		section := SectionDefinition{
-			Path: tablePath(table, headingRootLevel),
+			Path: path,
 		}
 		section.Variables = make([]VariableDefinition, 0)
+		documented := make(map[string]bool)
 		for _, row := range table.FindAll("tr")[1:] {
 			cells := row.FindAll("td")
 			if len(cells) != 4 {
 				continue
 			}
 
+			name := cells[0].FullText()
+			if documented[name] {
+				fmt.Fprintf(os.Stderr, "Variable %s is documented twice in section %s, ignoring the second definition\n", name, strings.Join(section.Path, ":"))
+				continue
+			}
+			documented[name] = true
+
 			section.Variables = append(section.Variables, VariableDefinition{
-				Name:        cells[0].FullText(),
-				Description: cells[1].FullText(),
-				Type:        cells[2].FullText(),
-				Default:     cells[3].FullText()})
+				Name:         name,
+				Description:  cells[1].FullText(),
+				Type:         cells[2].FullText(),
+				Default:      html.UnescapeString(cells[3].FullText()),
+				Example:      exampleFromDescription(cells[1], cells[2].FullText()),
+				EnumValues:   enumValuesFromDescription(cells[1]),
+				Range:        rangeFromDescription(cells[1].FullText()),
+				Unit:         unitFromDescription(cells[1].FullText(), cells[2].FullText()),
+				SinceVersion: sinceVersionFromDescription(cells[1].FullText()),
+			})
+		}
+		// Code spans referring to other variables of the section are not examples
+		for i, variable := range section.Variables {
+			if section.VariableDefinition(variable.Example) != nil {
+				section.Variables[i].Example = ""
+			}
 		}
 		sections = append(sections, section)
 	}
@@ -176,24 +443,51 @@ func parseDocumentationMarkdown(source []byte, headingRootLevel int) (sections [
 			sections[i] = section.AttachSubsections(sections)
 		}
 	}
-	return sections
+	return sections, errors.Join(skipped...)
 }
 
+// AttachSubsections returns s with its subsections taken from sections, recursively.
+// A section is a subsection of s if its path is s's path plus one more element.
 func (s SectionDefinition) AttachSubsections(sections []SectionDefinition) SectionDefinition {
-	// TODO make it work for recursively nested sections
 	s.Subsections = make([]SectionDefinition, 0)
 	for _, section := range sections {
-		if len(section.Path) == 1 {
+		if len(section.Path) != len(s.Path)+1 || !arraysEqual(section.Path[:len(s.Path)], s.Path) {
 			continue
 		}
-		if section.Path[0] == s.Name() {
-			debug("adding %s to %s\n", section.Name(), s.Name())
-			s.Subsections = append(s.Subsections, section)
-		}
+		debug("adding %s to %s\n", section.Name(), s.Name())
+		s.Subsections = append(s.Subsections, section.AttachSubsections(sections))
 	}
 	return s
 }
 
+var exampleValuePatterns = map[string]*regexp.Regexp{
+	"int":        regexp.MustCompile(`^-?\d+$`),
+	"float":      regexp.MustCompile(`^[-+]?\d*\.?\d+$`),
+	"floatvalue": regexp.MustCompile(`^[-+]?\d*\.?\d+$`),
+	"bool":       regexp.MustCompile(`^(true|false|yes|no|on|off|0|1)$`),
+	"color":      regexp.MustCompile(`^(rgba?\([0-9a-fA-F]+\)|0x[0-9a-fA-F]{8})$`),
+	"gradient":   regexp.MustCompile(`(rgba?\([0-9a-fA-F]+\)|0x[0-9a-fA-F]{8})`),
+	"vec2":       regexp.MustCompile(`^-?[\d.]+ -?[\d.]+$`),
+	"MOD":        regexp.MustCompile(`^[A-Z_ +]+$`),
+	// Code spans with spaces, colons or equal signs are usually commands or references to other variables
+	"str": regexp.MustCompile(`^[^\s:=]+$`),
+}
+
+// exampleFromDescription returns the first code span of the description that looks like a value of the given type
+func exampleFromDescription(description soup.Root, typ string) string {
+	pattern, ok := exampleValuePatterns[typ]
+	if !ok {
+		return ""
+	}
+
+	for _, code := range description.FindAll("code") {
+		if candidate := strings.TrimSpace(code.FullText()); pattern.MatchString(candidate) {
+			return candidate
+		}
+	}
+	return ""
+}
+
 func tableHeaderCells(table soup.Root) []string {
 	headerCells := table.FindAll("th")
 	cells := make([]string, 0, len(headerCells))
@@ -203,60 +497,133 @@ func tableHeaderCells(table soup.Root) []string {
 	return cells
 }
 
-func tablePath(table soup.Root, headingRootLevel int) []string {
-	header := backtrackToNearestHeader(table)
-	level, err := strconv.Atoi(header.NodeValue[1:])
+// errNoHeading is returned when looking for the heading of an element that has none before it
+var errNoHeading = errors.New("no heading before element")
+
+func tablePath(table soup.Root, headingRootLevel int) ([]string, error) {
+	header, err := backtrackToNearestHeader(table)
+	if err != nil {
+		return nil, err
+	}
+	return headingPath(header, headingRootLevel)
+}
+
+// headingPath returns the text of heading, preceded by the text of the headings it is nested in, up to the root level
+func headingPath(heading soup.Root, headingRootLevel int) ([]string, error) {
+	level, err := headingLevel(heading)
+	if err != nil {
+		return nil, fmt.Errorf("while getting the level of heading %q: %w", headingText(heading), err)
+	}
+	if level < headingRootLevel {
+		fmt.Fprintf(os.Stderr, "Heading %q is at level %d, above the root level %d of its document\n", headingText(heading), level, headingRootLevel)
+		return []string{"Unknown"}, nil
+	}
+	if level == headingRootLevel {
+		return []string{headingText(heading)}, nil
+	}
+
+	// The parent is the closest heading of a lower level, deeper headings in between belong to previous sibling sections
+	parent, err := backtrackToNearestHeader(heading.FindPrevElementSibling())
+	for err == nil {
+		if parentLevel, levelErr := headingLevel(parent); levelErr == nil && parentLevel < level {
+			break
+		}
+		parent, err = backtrackToNearestHeader(parent.FindPrevElementSibling())
+	}
 	if err != nil {
-		panic(err)
+		return nil, fmt.Errorf("while getting the parent sections of %q: %w", headingText(heading), err)
 	}
-	if level <= headingRootLevel {
-		return []string{header.FullText()}
+
+	parentPath, err := headingPath(parent, headingRootLevel)
+	if err != nil {
+		return nil, err
 	}
-	return append(tablePath(header.FindPrevElementSibling(), headingRootLevel), header.FullText())
+	return append(parentPath, headingText(heading)), nil
 }
 
-func backtrackToNearestHeader(element soup.Root) soup.Root {
+// backtrackToNearestHeader returns the closest heading before element, or errNoHeading if there is none.
+func backtrackToNearestHeader(element soup.Root) (soup.Root, error) {
+	if element.Pointer == nil {
+		return soup.Root{}, errNoHeading
+	}
 	if element.NodeValue != "table" {
 		debug("backtracking to nearest header from %s\n", element.HTML())
 	}
 	if regexp.MustCompile(`^h[1-6]$`).MatchString(element.NodeValue) {
 		debug("-> returning from backtrack with %s\n", element.HTML())
-		return element
+		return element, nil
 	}
 	prev := element.FindPrevElementSibling()
+	if prev.Pointer == nil {
+		return soup.Root{}, errNoHeading
+	}
 	debug("-> prev is %s\n", prev.HTML())
 	return backtrackToNearestHeader(prev)
 }
 
-func htmlBetweenHeadingAndNextHeading(heading soup.Root, element soup.Root) string {
+// htmlBetweenHeadingAndNextHeading returns the HTML of the elements after element, up to the next heading of the same level as heading.
+// If an element fails to render, the HTML of the elements before it is returned along with the error.
+func htmlBetweenHeadingAndNextHeading(heading soup.Root, element soup.Root) (string, error) {
 	next := element.FindNextElementSibling()
-	if isHeading(next) && headingLevel(next) == headingLevel(heading) {
-		return ""
+	if next.Pointer == nil {
+		return "", nil
+	}
+	if isHeading(next) {
+		level, err := headingLevel(heading)
+		if err != nil {
+			return "", err
+		}
+		nextLevel, err := headingLevel(next)
+		if err != nil {
+			return "", err
+		}
+		if level == nextLevel {
+			return "", nil
+		}
+	}
+
+	rendered, err := renderHTML(next)
+	if err != nil {
+		return "", fmt.Errorf("while rendering <%s>: %w", next.NodeValue, err)
 	}
 
+	following, err := htmlBetweenHeadingAndNextHeading(heading, next)
+	return rendered + following, err
+}
+
+// renderHTML returns the HTML of element, turning panics of the renderer into errors
+func renderHTML(element soup.Root) (html string, err error) {
 	defer func() {
 		if crash := recover(); crash != nil {
-			if os.Getenv("DEBUG") != "" {
-				fmt.Fprintf(os.Stderr, "Panic while rendering %s\n", next.HTML())
-			}
+			err = fmt.Errorf("renderer panicked: %v", crash)
 		}
 	}()
 
-	rendered := next.HTML()
+	return element.HTML(), nil
+}
+
+var atxClosingSequencePattern = regexp.MustCompile(`\s+#+\s*$`)
 
-	return rendered + htmlBetweenHeadingAndNextHeading(heading, next)
+// headingText returns the text of the heading, without the closing #s of ATX headings such as ## Section ##
+func headingText(heading soup.Root) string {
+	return atxClosingSequencePattern.ReplaceAllString(strings.TrimSpace(heading.FullText()), "")
+}
+
+// headingSlug returns the anchor of the heading, as generated by the wiki
+func headingSlug(heading soup.Root) string {
+	slug := slugify.Marshal(headingText(heading), true)
+	return regexp.MustCompile(`^weight-%d+-title-`).ReplaceAllString(slug, "")
 }
 
 func isHeading(element soup.Root) bool {
 	return regexp.MustCompile(`^h[1-6]$`).MatchString(element.NodeValue)
 }
 
-func headingLevel(heading soup.Root) int {
-	level, err := strconv.Atoi(heading.NodeValue[1:])
-	if err != nil {
-		panic(err)
+func headingLevel(heading soup.Root) (int, error) {
+	if !isHeading(heading) {
+		return 0, fmt.Errorf("<%s> is not a heading", heading.NodeValue)
 	}
-	return level
+	return strconv.Atoi(heading.NodeValue[1:])
 }
 
 func arraysEqual(a, b []string) bool {
@@ -274,7 +641,113 @@ func arraysEqual(a, b []string) bool {
 func toPascalCase(s string) string {
 	out := ""
 	for _, word := range regexp.MustCompile(`[-_\.]`).Split(s, -1) {
-		out += strings.ToUpper(word[:1]) + word[1:]
+		first, size := utf8.DecodeRuneInString(word)
+		if size == 0 {
+			continue
+		}
+		out += string(unicode.ToUpper(first)) + word[size:]
 	}
 	return out
 }
+
+// enumValuesInBracketsPattern matches lists of values such as [dwindle/master]
+var enumValuesInBracketsPattern = regexp.MustCompile(`\[([^\]\s/]+(?:/[^\]\s/]+)+)\]`)
+
+// enumValuesAsPipedCodeSpansPattern matches lists of values such as `a` | `b` | `c`
+var enumValuesAsPipedCodeSpansPattern = regexp.MustCompile(`<code>[^<]+</code>(?:\s*\|\s*<code>[^<]+</code>)+`)
+
+var codeSpanPattern = regexp.MustCompile(`<code>([^<]+)</code>`)
+
+// enumValuesFromDescription returns the values listed in the description as the only ones accepted, if any.
+// Values are listed either as [a/b/c], `a` | `b` | `c` or as code spans after "one of", until the end of the sentence.
+func enumValuesFromDescription(description soup.Root) []string {
+	if match := enumValuesInBracketsPattern.FindStringSubmatch(description.FullText()); match != nil {
+		return strings.Split(match[1], "/")
+	}
+
+	html := description.HTML()
+	if match := enumValuesAsPipedCodeSpansPattern.FindString(html); match != "" {
+		return codeSpansIn(match)
+	}
+
+	if _, after, found := strings.Cut(html, "one of"); found {
+		sentence, _, _ := strings.Cut(after, ". ")
+		if values := codeSpansIn(sentence); len(values) > 0 {
+			return values
+		}
+	}
+
+	return nil
+}
+
+func codeSpansIn(html string) []string {
+	values := make([]string, 0)
+	for _, match := range codeSpanPattern.FindAllStringSubmatch(html, -1) {
+		values = append(values, strings.TrimSpace(match[1]))
+	}
+	return values
+}
+
+// sinceVersionPattern matches mentions of the version a variable appeared in, such as "added in v0.35.0" or "since 0.40"
+var sinceVersionPattern = regexp.MustCompile(`(?i)(?:added in|introduced in|available since|since) (?:hyprland )?v?(\d+(?:\.\d+)+)`)
+
+// sinceVersionFromDescription returns the version of Hyprland the description says the variable was added in, if any
+func sinceVersionFromDescription(description string) string {
+	match := sinceVersionPattern.FindStringSubmatch(description)
+	if match == nil {
+		return ""
+	}
+	return match[1]
+}
+
+// unitPatterns match the units stated in descriptions of numeric variables, such as "in layout px" or "in milliseconds".
+// More specific patterns come first.
+var unitPatterns = []struct {
+	pattern *regexp.Regexp
+	unit    string
+}{
+	{regexp.MustCompile(`\bpx per timepoint\b`), "pixels per timepoint"},
+	{regexp.MustCompile(`\brepeats per second\b`), "repeats per second"},
+	{regexp.MustCompile(`\bin (?:layout )?(?:px|pixels)\b`), "pixels"},
+	{regexp.MustCompile(`\b(?:in|how many) (?:ms|milliseconds)\b`), "milliseconds"},
+	{regexp.MustCompile(`\bin seconds\b`), "seconds"},
+}
+
+// unitFromDescription returns the unit stated in the description of a numeric variable, if any
+func unitFromDescription(description string, typ string) string {
+	if typ != "int" && typ != "float" {
+		return ""
+	}
+
+	for _, unit := range unitPatterns {
+		if unit.pattern.MatchString(description) {
+			return unit.unit
+		}
+	}
+	return ""
+}
+
+// rangePatterns match ranges stated in descriptions, such as [0.0 - 1.0] or "clamped to the range -1.0 to 1.0".
+// The documentation sometimes uses en dashes instead of hyphens, and minus signs instead of hyphen-minuses.
+var rangePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`\[([-−]?\d+(?:\.\d+)?)\s*[-–—]\s*([-−]?\d+(?:\.\d+)?)\]`),
+	regexp.MustCompile(`range ([-−]?\d+(?:\.\d+)?) to ([-−]?\d+(?:\.\d+)?)`),
+}
+
+// rangeFromDescription returns the range of values stated in the description, if any
+func rangeFromDescription(description string) *NumericRange {
+	for _, pattern := range rangePatterns {
+		match := pattern.FindStringSubmatch(description)
+		if match == nil {
+			continue
+		}
+
+		min, errMin := strconv.ParseFloat(strings.ReplaceAll(match[1], "−", "-"), 64)
+		max, errMax := strconv.ParseFloat(strings.ReplaceAll(match[2], "−", "-"), 64)
+		if errMin != nil || errMax != nil || min > max {
+			continue
+		}
+		return &NumericRange{Min: min, Max: max}
+	}
+	return nil
+}
@@ -0,0 +1,381 @@
+package parser_data
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/anaskhan96/soup"
+)
+
+func TestAttachSubsectionsNestsRecursively(t *testing.T) {
+	sections := []SectionDefinition{
+		{Path: []string{"Input"}},
+		{Path: []string{"Input", "Touchpad"}},
+		{Path: []string{"Input", "Touchpad", "Gestures"}},
+		{Path: []string{"Input", "Tablet"}},
+		{Path: []string{"Decoration", "Touchpad", "Gestures"}},
+	}
+
+	input := sections[0].AttachSubsections(sections)
+	if len(input.Subsections) != 2 {
+		t.Fatalf("expected 2 subsections in input, got %d", len(input.Subsections))
+	}
+
+	touchpad := input.Subsections[0]
+	if touchpad.Name() != "Touchpad" {
+		t.Fatalf("unexpected first subsection: %q", touchpad.Name())
+	}
+	if len(touchpad.Subsections) != 1 {
+		t.Fatalf("expected 1 subsection in input:touchpad, got %d", len(touchpad.Subsections))
+	}
+	if !arraysEqual(touchpad.Subsections[0].Path, []string{"Input", "Touchpad", "Gestures"}) {
+		t.Fatalf("unexpected subsection of input:touchpad: %v", touchpad.Subsections[0].Path)
+	}
+
+	if tablet := input.Subsections[1]; len(tablet.Subsections) != 0 {
+		t.Fatalf("expected no subsections in input:tablet, got %d", len(tablet.Subsections))
+	}
+}
+
+func TestAttachSubsectionsDoesNotAttachGrandchildren(t *testing.T) {
+	sections := []SectionDefinition{
+		{Path: []string{"Decoration"}},
+		{Path: []string{"Decoration", "Blur", "Special"}},
+	}
+
+	decoration := sections[0].AttachSubsections(sections)
+	if len(decoration.Subsections) != 0 {
+		t.Fatalf("expected decoration:blur:special to not be attached to decoration directly, got %v", decoration.Subsections)
+	}
+}
+
+func TestParseDocumentationMarkdownWithoutHeadings(t *testing.T) {
+	sections, _ := parseDocumentationMarkdown([]byte("| name | description | type | default |\n| --- | --- | --- | --- |\n| a | b | int | 0 |\n"), 3)
+	if len(sections) != 0 {
+		t.Fatalf("expected tables without a heading to be skipped, got %v", sections)
+	}
+}
+
+func TestParseDocumentationMarkdownEnumValues(t *testing.T) {
+	sections, _ := parseDocumentationMarkdown([]byte(`### Section
+
+| name | description | type | default |
+| --- | --- | --- | --- |
+| layout | which layout to use. [dwindle/master] | str | dwindle |
+| method | Can be one of `+"`a`, `b`"+`. Not `+"`c`"+` | str | a |
+| size | the size | int | 0 |
+`), 3)
+	if len(sections) != 1 {
+		t.Fatalf("expected 1 section, got %d", len(sections))
+	}
+
+	for name, expected := range map[string][]string{
+		"layout": {"dwindle", "master"},
+		"method": {"a", "b"},
+		"size":   nil,
+	} {
+		if actual := sections[0].VariableDefinition(name).EnumValues; !arraysEqual(actual, expected) {
+			t.Errorf("expected enum values of %s to be %v, got %v", name, expected, actual)
+		}
+	}
+}
+
+func TestParseDocumentationMarkdownHeadingAboveRootLevel(t *testing.T) {
+	sections, _ := parseDocumentationMarkdown([]byte("## Too high\n\n| name | description | type | default |\n| --- | --- | --- | --- |\n| a | b | int | 0 |\n"), 3)
+	if len(sections) != 1 {
+		t.Fatalf("expected 1 section, got %d", len(sections))
+	}
+	if !arraysEqual(sections[0].Path, []string{"Unknown"}) {
+		t.Fatalf("expected path to be [Unknown], got %v", sections[0].Path)
+	}
+}
+
+func TestHeadingsWithClosingSequence(t *testing.T) {
+	document, _ := markdownToHTML([]byte("## Window Rules V2 ##\n\n### Layer rules #\n"))
+	for tag, expected := range map[string]string{"h2": "window-rules-v2", "h3": "layer-rules"} {
+		heading := document.Find(tag)
+		if heading.Error != nil {
+			t.Fatalf("could not find %s: %s", tag, heading.Error)
+		}
+		if slug := headingSlug(heading); slug != expected {
+			t.Errorf("expected slug of %s to be %q, got %q", tag, expected, slug)
+		}
+	}
+}
+
+func TestHeadingLevelOfNonHeading(t *testing.T) {
+	document, _ := markdownToHTML([]byte("Some paragraph\n"))
+	if _, err := headingLevel(document.Find("p")); err == nil {
+		t.Fatal("expected an error for a paragraph")
+	}
+	if _, err := headingLevel(soup.Root{}); err == nil {
+		t.Fatal("expected an error for an empty element")
+	}
+}
+
+func TestParseDocumentationMarkdownWithClosingSequences(t *testing.T) {
+	sections, _ := parseDocumentationMarkdown([]byte("### Section ###\n\n| name | description | type | default |\n| --- | --- | --- | --- |\n| a | b | int | 0 |\n"), 3)
+	if len(sections) != 1 || !arraysEqual(sections[0].Path, []string{"Section"}) {
+		t.Fatalf("expected a single section named Section, got %v", sections)
+	}
+}
+
+func TestMarkDeprecatedSections(t *testing.T) {
+	sections := []SectionDefinition{
+		{Path: []string{"Decoration"}, Subsections: []SectionDefinition{
+			{Path: []string{"Decoration", "Blur"}},
+		}},
+		{Path: []string{"Decoration", "Blur"}},
+		{Path: []string{"Input"}},
+	}
+
+	markDeprecatedSections(sections, map[string]string{"Blur": "use decoration:shadow instead"})
+
+	if !sections[1].Deprecated || sections[1].DeprecatedMessage != "use decoration:shadow instead" {
+		t.Fatalf("expected decoration:blur to be deprecated, got %+v", sections[1])
+	}
+	if !sections[0].Subsections[0].Deprecated {
+		t.Fatal("expected decoration:blur to be deprecated when nested in decoration")
+	}
+	if sections[0].Deprecated || sections[2].Deprecated {
+		t.Fatal("expected other sections not to be deprecated")
+	}
+}
+
+func TestRangeFromDescriptionWithTypographicDashes(t *testing.T) {
+	r := rangeFromDescription("how much to dim [−1.0 – 1.0]")
+	if r == nil || r.Min != -1 || r.Max != 1 {
+		t.Fatalf("expected range -1 to 1, got %+v", r)
+	}
+}
+
+func TestToPascalCaseWithUnicode(t *testing.T) {
+	if got := toPascalCase("élan_vital__x"); got != "ÉlanVitalX" {
+		t.Fatalf("unexpected pascal case: %q", got)
+	}
+}
+
+func TestVariableDefinitionLookup(t *testing.T) {
+	sections := []SectionDefinition{
+		{Path: []string{"General"}, Variables: []VariableDefinition{
+			{Name: "gaps_in", Type: "int", Default: "5"},
+			{Name: "gaps_out", Type: "int", Default: "20"},
+		}},
+	}
+
+	for _, indexed := range []bool{false, true} {
+		if indexed {
+			indexVariables(sections)
+		}
+
+		def := sections[0].VariableDefinition("gaps_out")
+		if def == nil || def.Default != "20" {
+			t.Fatalf("expected to find gaps_out (indexed=%v), got %+v", indexed, def)
+		}
+		if def := sections[0].VariableDefinition("border_size"); def != nil {
+			t.Fatalf("expected not to find border_size (indexed=%v), got %+v", indexed, def)
+		}
+	}
+}
+
+func TestParseDocumentationMarkdownUnescapesDefaults(t *testing.T) {
+	sections, _ := parseDocumentationMarkdown([]byte("### Section\n\n| name | description | type | default |\n| --- | --- | --- | --- |\n| a | b | str | a &amp; b |\n| c | d | str | `c &amp; d` |\n"), 3)
+	if len(sections) != 1 {
+		t.Fatalf("expected 1 section, got %d", len(sections))
+	}
+
+	for name, expected := range map[string]string{"a": "a & b", "c": "c & d"} {
+		if actual := sections[0].VariableDefinition(name).Default; actual != expected {
+			t.Errorf("expected default of %s to be %q, got %q", name, expected, actual)
+		}
+	}
+}
+
+// BenchmarkParseDocumentation measures what loading the package used to cost on startup, before the documentation was loaded lazily and pre-parsed
+func BenchmarkParseDocumentation(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		parseDocumentation()
+	}
+}
+
+// BenchmarkDecodeDocumentation measures what EnsureLoaded costs on the first use of the package
+func BenchmarkDecodeDocumentation(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if err := decodeDocumentation(encodedDocumentation); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestVariableCount(t *testing.T) {
+	section := SectionDefinition{
+		Variables: []VariableDefinition{{Name: "a"}, {Name: "b"}},
+		Subsections: []SectionDefinition{
+			{Variables: []VariableDefinition{{Name: "c"}}},
+			{Subsections: []SectionDefinition{{Variables: []VariableDefinition{{Name: "d"}}}}},
+		},
+	}
+	if count := section.VariableCount(); count != 4 {
+		t.Fatalf("expected 4 variables, got %d", count)
+	}
+}
+
+func TestParseDocumentationMarkdownKeepsFirstDuplicateVariable(t *testing.T) {
+	sections, _ := parseDocumentationMarkdown([]byte("### Section\n\n| name | description | type | default |\n| --- | --- | --- | --- |\n| a | first | int | 0 |\n| b | other | int | 0 |\n| a | second | int | 1 |\n"), 3)
+	if len(sections) != 1 || len(sections[0].Variables) != 2 {
+		t.Fatalf("expected a single section with 2 variables, got %v", sections)
+	}
+	if description := sections[0].VariableDefinition("a").Description; description != "first" {
+		t.Fatalf("expected the first definition of a to be kept, got %q", description)
+	}
+}
+
+func TestParseDocumentationMarkdownNestsThreeLevels(t *testing.T) {
+	table := "\n\n| name | description | type | default |\n| --- | --- | --- | --- |\n| a | b | int | 0 |\n\n"
+	sections, err := parseDocumentationMarkdown([]byte("### Decoration"+table+"#### Shadow"+table+"##### Inner"+table+"#### Blur"+table), 3)
+	if err != nil {
+		t.Fatalf("while parsing: %s", err)
+	}
+
+	decoration := sections[0]
+	if len(decoration.Subsections) != 2 {
+		t.Fatalf("expected 2 subsections in decoration, got %v", decoration.Subsections)
+	}
+	shadow := decoration.Subsections[0]
+	if len(shadow.Subsections) != 1 || !arraysEqual(shadow.Subsections[0].Path, []string{"Decoration", "Shadow", "Inner"}) {
+		t.Fatalf("expected decoration:shadow:inner in decoration:shadow, got %v", shadow.Subsections)
+	}
+	if decoration.VariableCount() != 4 {
+		t.Fatalf("expected 4 variables in decoration and its subsections, got %d", decoration.VariableCount())
+	}
+}
+
+func TestAllVariables(t *testing.T) {
+	section := SectionDefinition{
+		Path:      []string{"Decoration"},
+		Variables: []VariableDefinition{{Name: "rounding"}},
+		Subsections: []SectionDefinition{
+			{
+				Path:        []string{"Decoration", "Blur"},
+				Variables:   []VariableDefinition{{Name: "enabled"}},
+				Subsections: []SectionDefinition{{Path: []string{"Decoration", "Blur", "Special"}, Variables: []VariableDefinition{{Name: "size"}}}},
+			},
+		},
+	}
+
+	names := make([]string, 0)
+	for _, variable := range section.AllVariables() {
+		names = append(names, variable.Name)
+	}
+	if expected := []string{"rounding", "blur:enabled", "blur:special:size"}; !arraysEqual(names, expected) {
+		t.Fatalf("expected %v, got %v", expected, names)
+	}
+	if section.Subsections[0].Variables[0].Name != "enabled" {
+		t.Fatal("expected the subsection's variables to be left untouched")
+	}
+}
+
+func TestGetSectionsReturnsACopy(t *testing.T) {
+	sections := GetSections()
+	name := sections[0].Variables[0].Name
+	sections[0].Variables[0].Name = "changed"
+	sections[0].Path[0] = "Changed"
+
+	if again := GetSections(); again[0].Variables[0].Name != name || again[0].Name() == "Changed" {
+		t.Fatal("expected changes to the returned sections not to affect the package's")
+	}
+}
+
+func TestGetSectionsReturnsADeepCopy(t *testing.T) {
+	input := GetSections()[slices.IndexFunc(GetSections(), func(s SectionDefinition) bool { return s.Name() == "Input" })]
+	accelProfile := slices.IndexFunc(input.Variables, func(v VariableDefinition) bool { return v.Name == "accel_profile" })
+	sensitivity := slices.IndexFunc(input.Variables, func(v VariableDefinition) bool { return v.Name == "sensitivity" })
+	input.Variables[accelProfile].EnumValues[0] = "changed"
+	input.Variables[sensitivity].Range.Max = 100
+	// Swapping variables of a copy must not break the lookups of the package's sections, nor the copy's
+	input.Variables[accelProfile], input.Variables[sensitivity] = input.Variables[sensitivity], input.Variables[accelProfile]
+
+	original := FindSectionDefinitionByName("Input")
+	if original.VariableDefinition("accel_profile").EnumValues[0] == "changed" || original.VariableDefinition("sensitivity").Range.Max == 100 {
+		t.Error("expected changes to the variables of the returned sections not to affect the package's")
+	}
+	if found := input.VariableDefinition("accel_profile"); found == nil || found.Name != "accel_profile" {
+		t.Errorf("expected the copy's variables to be looked up in the copy, got %#v", found)
+	}
+}
+
+func TestSinceVersionFromDescription(t *testing.T) {
+	for description, expected := range map[string]string{
+		"enables the thing. Added in v0.35.0":         "0.35.0",
+		"enables the thing (since 0.40)":              "0.40",
+		"introduced in Hyprland 0.41.2, does things":  "0.41.2",
+		"ignores the 0.5 first pixels":                "",
+		"since the window opened, counts the seconds": "",
+	} {
+		if actual := sinceVersionFromDescription(description); actual != expected {
+			t.Errorf("expected version of %q to be %q, got %q", description, expected, actual)
+		}
+	}
+}
+
+func TestUnitFromDescription(t *testing.T) {
+	for description, expected := range map[string]string{
+		"rounded corners' radius (in layout px)":                     "pixels",
+		"minimum speed in px per timepoint to force the change":      "pixels per timepoint",
+		"Delay before a held-down key is repeated, in milliseconds.": "milliseconds",
+		"in ms, how many ms to wait after a scroll event":            "milliseconds",
+		"sets the timeout in seconds for watchdog":                   "seconds",
+		"If pixel opacity is below set value, will not blur.":        "",
+	} {
+		if actual := unitFromDescription(description, "int"); actual != expected {
+			t.Errorf("expected unit of %q to be %q, got %q", description, expected, actual)
+		}
+	}
+
+	if actual := unitFromDescription("if enabled, the tablet will be rotated 180 degrees, in px", "bool"); actual != "" {
+		t.Errorf("expected booleans to have no unit, got %q", actual)
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"0.35.0", "0.35", 0},
+		{"0.35.0", "0.36.0", -1},
+		{"v0.40.1", "0.40.0", 1},
+		{"1.0", "0.99.9", 1},
+	}
+	for _, c := range cases {
+		if got := CompareVersions(c.a, c.b); got != c.want {
+			t.Errorf("CompareVersions(%q, %q) = %d, want %d", c.a, c.b, got, c.want)
+		}
+	}
+}
+
+func TestParseDocumentationMarkdownSinceVersion(t *testing.T) {
+	sections, _ := parseDocumentationMarkdown([]byte(`### Section
+
+| name | description | type | default |
+| --- | --- | --- | --- |
+| new_thing | enables the new thing. _Added in v0.41.0_ | bool | false |
+| old_thing | enables the old thing | bool | false |
+`), 3)
+	if len(sections) != 1 {
+		t.Fatalf("expected 1 section, got %d", len(sections))
+	}
+
+	if actual := sections[0].VariableDefinition("new_thing").SinceVersion; actual != "0.41.0" {
+		t.Errorf("expected new_thing to be added in 0.41.0, got %q", actual)
+	}
+	if actual := sections[0].VariableDefinition("old_thing").SinceVersion; actual != "" {
+		t.Errorf("expected the version of old_thing to be unknown, got %q", actual)
+	}
+	if !anyVersionedVariable(sections) {
+		t.Error("expected the section to have versioned variables")
+	}
+	if anyVersionedVariable([]SectionDefinition{{Variables: []VariableDefinition{{Name: "old_thing"}}}}) {
+		t.Error("expected no versioned variables")
+	}
+}
@@ -0,0 +1,61 @@
+package parser_data
+
+type MonitorKeyword struct {
+	Value       string
+	Description string
+}
+
+type MonitorField struct {
+	Name        string
+	Description string
+	// Keywords are the special values the field accepts, besides the ones described in Description
+	Keywords []MonitorKeyword
+}
+
+// KeywordValues returns the values of the field's keywords, e.g. preferred, highres... for the resolution
+func (f MonitorField) KeywordValues() []string {
+	values := make([]string, 0, len(f.Keywords))
+	for _, keyword := range f.Keywords {
+		values = append(values, keyword.Value)
+	}
+	return values
+}
+
+// MonitorFields are the fields of monitor = NAME, RESOLUTION, POSITION, SCALE, in order.
+// See https://wiki.hyprland.org/Configuring/Monitors/#general
+var MonitorFields = []MonitorField{
+	{
+		Name:        "name",
+		Description: "name of the output, e.g. DP-1, or desc: followed by its description. Leave empty to define a fallback rule for monitors that no other rule matches.",
+	},
+	{
+		Name:        "resolution",
+		Description: "WIDTHxHEIGHT, optionally followed by @REFRESHRATE, e.g. 1920x1080@144.",
+		Keywords: []MonitorKeyword{
+			{Value: "preferred", Description: "use the display's preferred size"},
+			{Value: "highres", Description: "use the best possible resolution"},
+			{Value: "highrr", Description: "use the best possible refresh rate"},
+			{Value: "modeline", Description: "use a custom modeline, given after it"},
+			{Value: "disable", Description: "disable the monitor, no other fields are needed"},
+			{Value: "addreserved", Description: "add a reserved area, followed by TOP, BOTTOM, LEFT and RIGHT in pixels instead of the other fields"},
+		},
+	},
+	{
+		Name:        "position",
+		Description: "XxY position of the monitor in the layout, from the top-left corner, e.g. 1920x0. Can be negative.",
+		Keywords: []MonitorKeyword{
+			{Value: "auto", Description: "let Hyprland decide"},
+			{Value: "auto-right", Description: "put the monitor to the right of the others"},
+			{Value: "auto-left", Description: "put the monitor to the left of the others"},
+			{Value: "auto-up", Description: "put the monitor above the others"},
+			{Value: "auto-down", Description: "put the monitor below the others"},
+		},
+	},
+	{
+		Name:        "scale",
+		Description: "scaling factor, e.g. 1 or 1.5.",
+		Keywords: []MonitorKeyword{
+			{Value: "auto", Description: "let Hyprland decide, depending on the monitor's PPI"},
+		},
+	},
+}
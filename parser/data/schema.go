@@ -0,0 +1,93 @@
+package parser_data
+
+import (
+	"strconv"
+	"strings"
+)
+
+// JSONSchemaDraft07 is the meta-schema of the schemas returned by JSONSchema
+const JSONSchemaDraft07 = "http://json-schema.org/draft-07/schema#"
+
+// JSONSchema returns a JSON Schema describing configurations as objects with a property per section, e.g. for editors or validators of configurations converted to JSON or YAML.
+// Each section, subsections included, is defined in $defs by its path, e.g. decoration:blur, with a property per variable and per subsection.
+func JSONSchema() map[string]any {
+	definitions := make(map[string]any)
+	var define func(section SectionDefinition) map[string]any
+	define = func(section SectionDefinition) map[string]any {
+		name := strings.ToLower(strings.Join(section.Path, ":"))
+		properties := make(map[string]any)
+		for _, variable := range section.Variables {
+			if !variable.Deprecated {
+				properties[variable.Name] = variableJSONSchema(variable)
+			}
+		}
+		for _, subsection := range section.Subsections {
+			properties[subsection.JSONName()] = define(subsection)
+		}
+
+		definitions[name] = map[string]any{
+			"type":                 "object",
+			"properties":           properties,
+			"additionalProperties": false,
+		}
+		return map[string]any{"$ref": "#/$defs/" + name}
+	}
+
+	properties := make(map[string]any)
+	for _, section := range GetSections() {
+		properties[section.JSONName()] = define(section)
+	}
+
+	return map[string]any{
+		"$schema":    JSONSchemaDraft07,
+		"title":      "Hyprland configuration",
+		"type":       "object",
+		"properties": properties,
+		"$defs":      definitions,
+	}
+}
+
+func variableJSONSchema(variable VariableDefinition) map[string]any {
+	schema := map[string]any{"description": variable.Description}
+	if len(variable.EnumValues) > 0 {
+		schema["enum"] = variable.EnumValues
+	} else {
+		schema["type"] = variable.JSONSchemaType()
+	}
+
+	if variable.Range != nil {
+		schema["minimum"] = variable.Range.Min
+		schema["maximum"] = variable.Range.Max
+	}
+
+	if def, ok := jsonSchemaDefault(variable); ok {
+		schema["default"] = def
+	}
+	return schema
+}
+
+// jsonSchemaDefault returns the variable's default value as a value of its JSON Schema type, if it can be converted
+func jsonSchemaDefault(variable VariableDefinition) (any, bool) {
+	if variable.Default == "" || variable.Default == "[[Empty]]" {
+		return nil, false
+	}
+
+	switch variable.JSONSchemaType() {
+	case "integer":
+		value, err := strconv.Atoi(variable.Default)
+		return value, err == nil
+	case "number":
+		value, err := strconv.ParseFloat(variable.Default, 64)
+		return value, err == nil
+	case "boolean":
+		switch variable.Default {
+		case "true", "yes", "on", "1":
+			return true, true
+		case "false", "no", "off", "0":
+			return false, true
+		}
+		return nil, false
+	default:
+		return variable.Default, true
+	}
+}
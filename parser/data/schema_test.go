@@ -0,0 +1,52 @@
+package parser_data
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestJSONSchema(t *testing.T) {
+	schema := JSONSchema()
+	if _, err := json.Marshal(schema); err != nil {
+		t.Fatalf("while encoding: %s", err)
+	}
+
+	definitions := schema["$defs"].(map[string]any)
+	property := func(section, variable string) map[string]any {
+		t.Helper()
+		definition, ok := definitions[section].(map[string]any)
+		if !ok {
+			t.Fatalf("expected a definition for %s", section)
+		}
+		return definition["properties"].(map[string]any)[variable].(map[string]any)
+	}
+
+	if ref := schema["properties"].(map[string]any)["decoration"]; !reflect.DeepEqual(ref, map[string]any{"$ref": "#/$defs/decoration"}) {
+		t.Errorf("expected decoration to reference its definition, got %#v", ref)
+	}
+	if ref := property("decoration", "blur"); ref["$ref"] != "#/$defs/decoration:blur" {
+		t.Errorf("expected blur to reference its definition, got %#v", ref)
+	}
+
+	for _, c := range []struct {
+		section, variable, typ string
+	}{
+		{"general", "gaps_in", "integer"},
+		{"decoration", "active_opacity", "number"},
+		{"decoration:blur", "enabled", "boolean"},
+		{"input", "kb_layout", "string"},
+		{"general", "col.active_border", "string"},
+	} {
+		if typ := property(c.section, c.variable)["type"]; typ != c.typ {
+			t.Errorf("expected %s:%s to be of type %s, got %v", c.section, c.variable, c.typ, typ)
+		}
+	}
+
+	if layout := property("general", "layout"); !reflect.DeepEqual(layout["enum"], []string{"dwindle", "master"}) || layout["default"] != "dwindle" {
+		t.Errorf("expected the layouts to be enumerated, got %#v", layout)
+	}
+	if opacity := property("decoration", "active_opacity"); opacity["minimum"] != 0.0 || opacity["maximum"] != 1.0 {
+		t.Errorf("expected the range of active_opacity, got %#v", opacity)
+	}
+}
@@ -2,10 +2,13 @@ package parser_data
 
 import (
 	"fmt"
+	"slices"
 	"strings"
+	"sync"
 )
 
 func FindSectionDefinitionByName(name string) *SectionDefinition {
+	EnsureLoaded()
 	for _, sec := range Sections {
 		if sec.Name() == name || sec.JSONName() == name {
 			return &sec
@@ -14,10 +17,77 @@ func FindSectionDefinitionByName(name string) *SectionDefinition {
 	return nil
 }
 
+// GetSections returns a copy of the sections documented in the wiki, loading them if needed.
+// Changing the returned sections, their subsections or their variables does not affect this package.
+// Use LoadedSections instead to only read them.
+func GetSections() []SectionDefinition {
+	EnsureLoaded()
+	return cloneSections(Sections)
+}
+
+// LoadedSections returns the sections documented in the wiki, loading them if needed.
+// Unlike GetSections, it doesn't copy them, so they must not be changed.
+func LoadedSections() []SectionDefinition {
+	EnsureLoaded()
+	return Sections
+}
+
+// cloneSections deep-copies sections. Clones get their own index of variables, see indexVariables.
+func cloneSections(sections []SectionDefinition) []SectionDefinition {
+	if sections == nil {
+		return nil
+	}
+
+	cloned := make([]SectionDefinition, len(sections))
+	for i, section := range sections {
+		cloned[i] = section
+		cloned[i].Path = slices.Clone(section.Path)
+		cloned[i].Variables = cloneVariables(section.Variables)
+		cloned[i].Subsections = cloneSections(section.Subsections)
+		if section.variables != nil {
+			cloned[i].variables = &variableIndex{}
+		}
+	}
+	return cloned
+}
+
+func cloneVariables(variables []VariableDefinition) []VariableDefinition {
+	cloned := slices.Clone(variables)
+	for i, variable := range cloned {
+		cloned[i].EnumValues = slices.Clone(variable.EnumValues)
+		cloned[i].Suggestions = slices.Clone(variable.Suggestions)
+		if variable.Range != nil {
+			numericRange := *variable.Range
+			cloned[i].Range = &numericRange
+		}
+	}
+	return cloned
+}
+
 type SectionDefinition struct {
 	Path        []string
 	Subsections []SectionDefinition
 	Variables   []VariableDefinition
+	// Deprecated is true if the whole section was removed or moved in a later version of Hyprland
+	Deprecated bool
+	// DeprecatedMessage explains what to use instead of a deprecated section
+	DeprecatedMessage string
+	// variables indexes Variables by name, see indexVariables
+	variables *variableIndex
+}
+
+type variableIndex struct {
+	once   sync.Once
+	byName map[string]int
+}
+
+// indexVariables prepares the sections, and their subsections, to look up their variables by name without going through all of them.
+// The index itself is built on the first lookup, so Variables must not change after that.
+func indexVariables(sections []SectionDefinition) {
+	for i := range sections {
+		sections[i].variables = &variableIndex{}
+		indexVariables(sections[i].Subsections)
+	}
 }
 
 func (s SectionDefinition) Name() string {
@@ -27,6 +97,28 @@ func (s SectionDefinition) Name() string {
 	return s.Path[len(s.Path)-1]
 }
 
+// VariableCount returns the number of variables of the section, including the ones of its subsections
+func (s SectionDefinition) VariableCount() int {
+	count := len(s.Variables)
+	for _, subsection := range s.Subsections {
+		count += subsection.VariableCount()
+	}
+	return count
+}
+
+// AllVariables returns the variables of the section, followed by the ones of its subsections.
+// Variables of subsections are named relative to the section, with the path separated by colons like in configuration files: blur:enabled for the decoration section.
+func (s SectionDefinition) AllVariables() []VariableDefinition {
+	variables := append([]VariableDefinition{}, s.Variables...)
+	for _, subsection := range s.Subsections {
+		for _, variable := range subsection.AllVariables() {
+			variable.Name = subsection.JSONName() + ":" + variable.Name
+			variables = append(variables, variable)
+		}
+	}
+	return variables
+}
+
 func (s SectionDefinition) JSONName() string {
 	return strings.ToLower(s.Name())
 }
@@ -38,6 +130,9 @@ func (s SectionDefinition) TypeName() string {
 func (s SectionDefinition) Typedef() string {
 	out := fmt.Sprintf("type %s struct {\n", s.TypeName())
 	for _, def := range s.Variables {
+		if def.Deprecated {
+			continue
+		}
 		out += fmt.Sprintf("\t// %s\n", def.Description)
 		out += fmt.Sprintf("\t%s %s `json:\"%s\"`\n", def.PascalCaseName(), def.GoType(), def.Name)
 		out += "\n"
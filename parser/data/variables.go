@@ -1,5 +1,12 @@
 package parser_data
 
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
 func FindVariableDefinitionInSection(sectionName, variableName string) *VariableDefinition {
 	sec := FindSectionDefinitionByName(sectionName)
 	if sec == nil {
@@ -13,6 +20,83 @@ type VariableDefinition struct {
 	Description string
 	Type        string
 	Default     string
+	// Example is an example value, taken from the description
+	Example string
+	// EnumValues are the only values the variable accepts, if the description lists them
+	EnumValues []string
+	// Range is the range of values a numeric variable accepts, if the description states it
+	Range *NumericRange
+	// Suggestions are noteworthy values to propose when completing the variable's value
+	Suggestions []ValueSuggestion
+	// Deprecated is true if the variable was removed or renamed in a later version of Hyprland
+	Deprecated bool
+	// Unit is the unit of a numeric variable's value, e.g. pixels, if the description states it
+	Unit string
+	// SinceVersion is the version of Hyprland the variable was added in, e.g. 0.35.0. Empty if unknown.
+	SinceVersion string
+	// ReplacedWith is the full path of the variable replacing this deprecated one, e.g. decoration:blur:size. Empty if it was removed without replacement.
+	ReplacedWith string
+}
+
+type NumericRange struct {
+	Min float64
+	Max float64
+}
+
+func (r NumericRange) Contains(value float64) bool {
+	return r.Min <= value && value <= r.Max
+}
+
+type ValueSuggestion struct {
+	Value string
+	// Label explains what the value does, e.g. "fully transparent"
+	Label string
+}
+
+// versionedVariables is true if the documentation gives the version of Hyprland at least one variable was added in, see HasVersionedVariables
+var versionedVariables bool
+
+// HasVersionedVariables is true if the documentation gives the version of Hyprland at least one variable was added in.
+// Otherwise, no variable can be reported as unavailable in the installed version, so there is no need to look it up.
+func HasVersionedVariables() bool {
+	EnsureLoaded()
+	return versionedVariables
+}
+
+// anyVersionedVariable is true if a variable of the sections or of their subsections has a SinceVersion
+func anyVersionedVariable(sections []SectionDefinition) bool {
+	return slices.ContainsFunc(sections, func(section SectionDefinition) bool {
+		return slices.ContainsFunc(section.AllVariables(), func(variable VariableDefinition) bool { return variable.SinceVersion != "" })
+	})
+}
+
+// AvailableIn is true if the variable exists in the given version of Hyprland.
+// Variables whose SinceVersion is unknown are assumed to always exist.
+func (v VariableDefinition) AvailableIn(version string) bool {
+	return v.SinceVersion == "" || CompareVersions(v.SinceVersion, version) <= 0
+}
+
+// CompareVersions compares two dotted version numbers such as 0.35.0, returning -1, 0 or 1 like strings.Compare.
+// Missing components count as 0, so 0.35 and 0.35.0 are equal.
+func CompareVersions(a, b string) int {
+	aParts := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	bParts := strings.Split(strings.TrimPrefix(b, "v"), ".")
+	for i := 0; i < max(len(aParts), len(bParts)); i++ {
+		var aPart, bPart int
+		if i < len(aParts) {
+			aPart, _ = strconv.Atoi(aParts[i])
+		}
+		if i < len(bParts) {
+			bPart, _ = strconv.Atoi(bParts[i])
+		}
+		if aPart != bPart {
+			if aPart < bPart {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
 }
 
 func (v VariableDefinition) PrettyDefault() string {
@@ -22,6 +106,14 @@ func (v VariableDefinition) PrettyDefault() string {
 	return v.Default
 }
 
+// PrettyType returns the type of the variable, followed by its unit if known, e.g. int (pixels)
+func (v VariableDefinition) PrettyType() string {
+	if v.Unit == "" {
+		return v.Type
+	}
+	return fmt.Sprintf("%s (%s)", v.Type, v.Unit)
+}
+
 func (v VariableDefinition) GoType() string {
 	switch v.Type {
 	case "int":
@@ -71,6 +163,20 @@ func (v VariableDefinition) ParserTypeString() string {
 	}
 }
 
+// JSONSchemaType returns the JSON Schema type of the variable's values. Colors, gradients, vectors and modifiers are written as strings.
+func (v VariableDefinition) JSONSchemaType() string {
+	switch v.Type {
+	case "int":
+		return "integer"
+	case "bool":
+		return "boolean"
+	case "float", "floatvalue":
+		return "number"
+	default:
+		return "string"
+	}
+}
+
 func (v VariableDefinition) PascalCaseName() string {
 	return toPascalCase(v.Name)
 }
@@ -0,0 +1,7 @@
+package parser_data
+
+// WikiCommit is the commit of the hyprland-wiki submodule the documentation comes from, set at build time with -ldflags "-X github.com/ewen-lbh/hyprls/parser/data.WikiCommit=...", see the Justfile. Empty if unknown.
+var WikiCommit string
+
+// WikiHyprlandVersion is the Hyprland release the documentation corresponds to, e.g. v0.41.2, set at build time like WikiCommit. Empty if unknown.
+var WikiHyprlandVersion string
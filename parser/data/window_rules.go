@@ -0,0 +1,164 @@
+package parser_data
+
+import (
+	"strings"
+
+	"github.com/anaskhan96/soup"
+)
+
+// RuleDefinition is a rule that can be applied to windows or layers
+type RuleDefinition struct {
+	Name string
+	// Arguments describes the arguments the rule takes after its name, e.g. [x] [y]. Empty if it takes none.
+	Arguments   string
+	Description string
+}
+
+type WindowRuleDefinition struct {
+	RuleDefinition
+	// Dynamic rules are evaluated again when a property of the window changes, static ones only when the window opens
+	Dynamic bool
+}
+
+// WindowRules are the rules windowrule and windowrulev2 can apply to windows, see EnsureLoaded
+var WindowRules = []WindowRuleDefinition{}
+
+// LayerRules are the rules layerrule can apply to layers, such as bars and launchers, see EnsureLoaded
+var LayerRules = []RuleDefinition{}
+
+// parseWindowRules reads the tables of the Static rules and Dynamic rules sections
+func parseWindowRules(source []byte) ([]WindowRuleDefinition, error) {
+	document, err := markdownToHTML(source)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]WindowRuleDefinition, 0)
+	for _, table := range document.FindAll("table") {
+		if !arraysEqual(lowercased(tableHeaderCells(table)), []string{"rule", "description"}) {
+			continue
+		}
+
+		heading, err := backtrackToNearestHeader(table)
+		if err != nil {
+			continue
+		}
+		kind := headingText(heading)
+		if kind != "Static rules" && kind != "Dynamic rules" {
+			continue
+		}
+
+		for _, rule := range rulesOfTable(table) {
+			rules = append(rules, WindowRuleDefinition{RuleDefinition: rule, Dynamic: kind == "Dynamic rules"})
+		}
+	}
+	return rules, nil
+}
+
+// parseLayerRules reads the table of the Rules section of the Layer Rules section
+func parseLayerRules(source []byte) ([]RuleDefinition, error) {
+	document, err := markdownToHTML(source)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]RuleDefinition, 0)
+	for _, table := range document.FindAll("table") {
+		if !arraysEqual(lowercased(tableHeaderCells(table)), []string{"rule", "description"}) {
+			continue
+		}
+
+		path, err := tablePath(table, 2)
+		if err != nil || !arraysEqual(path, []string{"Layer Rules", "Rules"}) {
+			continue
+		}
+
+		rules = append(rules, rulesOfTable(table)...)
+	}
+	return rules, nil
+}
+
+// rulesOfTable reads a table of rules, documented as "name [arguments]" and their description
+func rulesOfTable(table soup.Root) []RuleDefinition {
+	rules := make([]RuleDefinition, 0)
+	for _, row := range table.FindAll("tr")[1:] {
+		cells := row.FindAll("td")
+		if len(cells) != 2 {
+			continue
+		}
+
+		name, arguments, _ := strings.Cut(strings.TrimSpace(cells[0].FullText()), " ")
+		rules = append(rules, RuleDefinition{
+			Name:        name,
+			Arguments:   strings.TrimSpace(arguments),
+			Description: cells[1].FullText(),
+		})
+	}
+	return rules
+}
+
+func FindWindowRule(name string) (WindowRuleDefinition, bool) {
+	EnsureLoaded()
+	for _, r := range WindowRules {
+		if r.Name == name {
+			return r, true
+		}
+	}
+	return WindowRuleDefinition{}, false
+}
+
+func FindLayerRule(name string) (RuleDefinition, bool) {
+	EnsureLoaded()
+	for _, r := range LayerRules {
+		if r.Name == name {
+			return r, true
+		}
+	}
+	return RuleDefinition{}, false
+}
+
+type WindowRuleFilterKind int
+
+const (
+	// WindowRuleFilterRegex filters match a regular expression against a property of the window
+	WindowRuleFilterRegex WindowRuleFilterKind = iota
+	// WindowRuleFilterBool filters accept 0 or 1
+	WindowRuleFilterBool
+	// WindowRuleFilterInteger filters accept a number
+	WindowRuleFilterInteger
+	// WindowRuleFilterWorkspace filters accept a workspace ID, name: and a name or a workspace selector
+	WindowRuleFilterWorkspace
+)
+
+type WindowRuleFilterDefinition struct {
+	Name        string
+	Description string
+	Kind        WindowRuleFilterKind
+}
+
+// WindowRuleFilters are the fields windowrulev2 = RULE, FIELD:VALUE, ... can match windows with.
+// See https://wiki.hyprland.org/Configuring/Window-Rules/#window-rules-v2
+var WindowRuleFilters = []WindowRuleFilterDefinition{
+	{Name: "class", Description: "class regex", Kind: WindowRuleFilterRegex},
+	{Name: "title", Description: "title regex", Kind: WindowRuleFilterRegex},
+	{Name: "initialclass", Description: "initialClass regex", Kind: WindowRuleFilterRegex},
+	{Name: "initialtitle", Description: "initialTitle regex", Kind: WindowRuleFilterRegex},
+	{Name: "tag", Description: "tag name", Kind: WindowRuleFilterRegex},
+	{Name: "xwayland", Description: "whether the window is an XWayland window, 0/1", Kind: WindowRuleFilterBool},
+	{Name: "floating", Description: "whether the window is floating, 0/1", Kind: WindowRuleFilterBool},
+	{Name: "fullscreen", Description: "whether the window is fullscreen, 0/1", Kind: WindowRuleFilterBool},
+	{Name: "pinned", Description: "whether the window is pinned, 0/1", Kind: WindowRuleFilterBool},
+	{Name: "focus", Description: "whether the window is focused, 0/1", Kind: WindowRuleFilterBool},
+	{Name: "pid", Description: "process ID of the window", Kind: WindowRuleFilterInteger},
+	{Name: "workspace", Description: "id or name: and name", Kind: WindowRuleFilterWorkspace},
+	{Name: "onworkspace", Description: "id, name: and name, or workspace selector (see Workspace Rules)", Kind: WindowRuleFilterWorkspace},
+}
+
+func FindWindowRuleFilter(name string) (WindowRuleFilterDefinition, bool) {
+	for _, f := range WindowRuleFilters {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return WindowRuleFilterDefinition{}, false
+}
@@ -0,0 +1,52 @@
+package parser_data
+
+import (
+	"strings"
+)
+
+type WorkspaceRuleDefinition struct {
+	Name        string
+	Description string
+	Type        string
+}
+
+// WorkspaceRules are the rules that can be given to a workspace in workspace = NAME, RULES..., see EnsureLoaded
+var WorkspaceRules = []WorkspaceRuleDefinition{}
+
+func parseWorkspaceRules(source []byte) ([]WorkspaceRuleDefinition, error) {
+	document, err := markdownToHTML(source)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]WorkspaceRuleDefinition, 0)
+	for _, table := range document.FindAll("table") {
+		if !arraysEqual(lowercased(tableHeaderCells(table)), []string{"rule", "description", "type"}) {
+			continue
+		}
+
+		for _, row := range table.FindAll("tr")[1:] {
+			cells := row.FindAll("td")
+			if len(cells) != 3 {
+				continue
+			}
+
+			// Rules are documented as name:[argument]
+			name, _, _ := strings.Cut(cells[0].FullText(), ":")
+			rules = append(rules, WorkspaceRuleDefinition{
+				Name:        name,
+				Description: cells[1].FullText(),
+				Type:        cells[2].FullText(),
+			})
+		}
+	}
+	return rules, nil
+}
+
+func lowercased(strs []string) []string {
+	result := make([]string, 0, len(strs))
+	for _, s := range strs {
+		result = append(result, strings.ToLower(s))
+	}
+	return result
+}
@@ -0,0 +1,85 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FormatIndentation is the indentation of each level of nested sections in formatted documents
+const FormatIndentation = "    "
+
+// Format returns the document, formatted:
+//   - lines are indented according to the depth of the section they are in
+//   - there is exactly one space around the = of assignments, keywords and custom variables definitions, and before the { of section headers
+//   - trailing whitespace is removed, and consecutive empty lines are collapsed into one
+//   - the document ends with a single newline
+//
+// Comments are kept as they are, only indented. Documents with unbalanced braces are not formatted.
+func Format(input string) (string, error) {
+	input = strings.TrimPrefix(input, ByteOrderMark)
+
+	formatted := make([]string, 0)
+	depth := 0
+	for i, line := range strings.Split(input, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			if len(formatted) > 0 && formatted[len(formatted)-1] != "" {
+				formatted = append(formatted, "")
+			}
+			continue
+		}
+
+		code, comment := splitComment(line)
+		// Sections are delimited the same way Parse does: headers end with { and closing braces are on their own line
+		switch {
+		case code == "}":
+			depth--
+			if depth < 0 {
+				return "", fmt.Errorf("line %d: closing brace without a matching opening brace", i+1)
+			}
+		case strings.HasSuffix(code, "{"):
+			code = strings.TrimSpace(strings.TrimSuffix(code, "{")) + " {"
+		case strings.Contains(code, "="):
+			key, value, _ := strings.Cut(code, "=")
+			code = strings.TrimRight(strings.TrimSpace(key)+" = "+strings.TrimSpace(value), " ")
+		}
+
+		lineDepth := depth
+		if strings.HasSuffix(code, "{") {
+			depth++
+		}
+
+		if comment != "" && code != "" {
+			code += " "
+		}
+		formatted = append(formatted, strings.Repeat(FormatIndentation, lineDepth)+code+comment)
+	}
+
+	if depth != 0 {
+		return "", fmt.Errorf("%d section(s) are not closed", depth)
+	}
+
+	for len(formatted) > 0 && formatted[len(formatted)-1] == "" {
+		formatted = formatted[:len(formatted)-1]
+	}
+	if len(formatted) == 0 {
+		return "", nil
+	}
+	return strings.Join(formatted, "\n") + "\n", nil
+}
+
+// splitComment splits the line into its code and its comment, which includes the #.
+// ## is an escaped #, not the start of a comment.
+func splitComment(line string) (code string, comment string) {
+	for i := 0; i < len(line); i++ {
+		if line[i] != '#' {
+			continue
+		}
+		if i+1 < len(line) && line[i+1] == '#' {
+			i++
+			continue
+		}
+		return strings.TrimSpace(line[:i]), line[i:]
+	}
+	return line, ""
+}
@@ -0,0 +1,31 @@
+package parser
+
+import "testing"
+
+func TestFormat(t *testing.T) {
+	input := ByteOrderMark + "$mod=SUPER  \n\n\n\ngeneral{\ngaps_in=5 # inner gaps\n  col.active_border   =   rgba(ff0000ff)\n# colors\ndecoration {\n\t\tblur  {\nsize=3\n}\n  }\n}\nbind=$mod, Q, exec, kitty\nwindowrulev2 = float, title:^(a=b)$\n\n\n"
+	expected := "$mod = SUPER\n\ngeneral {\n    gaps_in = 5 # inner gaps\n    col.active_border = rgba(ff0000ff)\n    # colors\n    decoration {\n        blur {\n            size = 3\n        }\n    }\n}\nbind = $mod, Q, exec, kitty\nwindowrulev2 = float, title:^(a=b)$\n"
+
+	formatted, err := Format(input)
+	if err != nil {
+		t.Fatalf("while formatting: %s", err)
+	}
+	if formatted != expected {
+		t.Errorf("expected\n%s\ngot\n%s", expected, formatted)
+	}
+
+	again, err := Format(formatted)
+	if err != nil || again != formatted {
+		t.Errorf("expected formatting to be idempotent, got\n%s", again)
+	}
+}
+
+func TestFormatUnbalancedBraces(t *testing.T) {
+	if _, err := Format("general {\n  gaps_in = 5\n"); err == nil {
+		t.Error("expected an error for an unclosed section")
+	}
+
+	if _, err := Format("gaps_in = 5\n}\n"); err == nil {
+		t.Error("expected an error for a stray closing brace")
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"strconv"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 
 	parser_data "github.com/ewen-lbh/hyprls/parser/data"
 	"go.lsp.dev/protocol"
@@ -16,10 +17,12 @@ import (
 var RootSection = "General"
 
 type Position struct {
-	Line   int `json:"line"`
+	Line int `json:"line"`
+	// Column is counted in characters, not in bytes
 	Column int `json:"column"`
 }
 
+// LSP returns the position with its column as the character, still counted in characters: the server converts it to the UTF-16 code units clients count in
 func (p Position) LSP() protocol.Position {
 	return protocol.Position{
 		Line:      uint32(p.Line),
@@ -170,6 +173,29 @@ func (v Value) GoValue() any {
 
 }
 
+func (k ValueKind) String() string {
+	switch k {
+	case Integer:
+		return "int"
+	case Bool:
+		return "bool"
+	case Float:
+		return "float"
+	case Color:
+		return "color"
+	case Vec2:
+		return "vec2"
+	case Modmask:
+		return "modmask"
+	case String:
+		return "string"
+	case Gradient:
+		return "gradient"
+	default:
+		return "custom"
+	}
+}
+
 func (k ValueKind) LSPSymbol() protocol.SymbolKind {
 	switch k {
 	case Integer:
@@ -209,7 +235,12 @@ func (v *Value) LSPColor() protocol.Color {
 	}
 }
 
+// ByteOrderMark is the UTF-8 byte order mark some editors write at the start of files
+const ByteOrderMark = "\uFEFF"
+
 func Parse(input string) (Section, error) {
+	// The byte order mark would otherwise end up in the first line's key
+	input = strings.TrimPrefix(input, ByteOrderMark)
 	document := Section{
 		Name:        RootSection,
 		Assignments: []Assignment{},
@@ -219,7 +250,6 @@ func Parse(input string) (Section, error) {
 
 	sectionsStack := []*Section{&document}
 	sectionDepth := 0
-	endLine := 0
 	for i, originalLine := range strings.Split(input, "\n") {
 		currentSection := sectionsStack[sectionDepth]
 		line := strings.TrimSpace(originalLine)
@@ -230,13 +260,13 @@ func Parse(input string) (Section, error) {
 		if strings.HasSuffix(line, "{") {
 			sectionDepth++
 			section := parseSectionStart(line)
-			section.Start = Position{i, strings.Index(line, "{")}
+			section.Start = Position{i, runeColumn(line, strings.Index(line, "{"))}
 			sectionsStack = append(sectionsStack, &section)
 		}
 
 		if strings.Contains(line, "=") {
 			ass, stmt, customVar, isStatement, isCustomVar := ParseEqualLine(line, originalLine, Position{i, 0})
-			pos := Position{i, strings.IndexFunc(line, unicode.IsPrint)}
+			pos := Position{i, runeColumn(originalLine, strings.IndexFunc(originalLine, not(unicode.IsSpace)))}
 			if isCustomVar {
 				customVar.Position = pos
 				currentSection.Variables = append(currentSection.Variables, customVar)
@@ -249,22 +279,36 @@ func Parse(input string) (Section, error) {
 			}
 		}
 
-		if line == "}" {
-			currentSection.End = Position{i, strings.Index(originalLine, "}")}
+		// A stray } at the root of the document closes nothing, ignore it
+		if line == "}" && sectionDepth > 0 {
+			currentSection.End = Position{i, runeColumn(originalLine, strings.Index(originalLine, "}"))}
 			sectionsStack[sectionDepth-1].Subsections = append(sectionsStack[sectionDepth-1].Subsections, *sectionsStack[sectionDepth])
 			sectionsStack = sectionsStack[:sectionDepth]
 			sectionDepth--
-			if sectionDepth < 0 {
-				panic("unbalanced section")
-			}
 		}
-		endLine = i
 	}
-	// FIXME 0 is incorrect, but do we care?
-	document.End = Position{endLine, 0}
+
+	lines := strings.Split(input, "\n")
+	document.End = Position{len(lines) - 1, utf8.RuneCountInString(lines[len(lines)-1])}
+
+	// Sections still open at the end of the document (e.g. while the user is typing) end with it
+	for ; sectionDepth > 0; sectionDepth-- {
+		sectionsStack[sectionDepth].End = document.End
+		sectionsStack[sectionDepth-1].Subsections = append(sectionsStack[sectionDepth-1].Subsections, *sectionsStack[sectionDepth])
+	}
+
 	return document, nil
 }
 
+// runeColumn converts a byte offset in line to a column counted in characters, as editors do.
+// Negative offsets, returned by strings.Index & co. when nothing is found, are kept as is.
+func runeColumn(line string, byteOffset int) int {
+	if byteOffset < 0 {
+		return byteOffset
+	}
+	return utf8.RuneCountInString(line[:byteOffset])
+}
+
 func ParseEqualLine(line string, originalLine string, start Position) (ass Assignment, stmt Statement, customVar CustomVariable, isStatement bool, isCustomVar bool) {
 	parts := strings.Split(line, "=")
 	// parts[1] = strings.SplitN(parts[1], " #", 2)[0]
@@ -277,8 +321,10 @@ func ParseEqualLine(line string, originalLine string, start Position) (ass Assig
 	encounteredEquals := false
 	encounteredValue := false
 	valueStart := start
-	valueEnd := Position{start.Line, strings.LastIndexFunc(originalLine, not(unicode.IsSpace))}
+	valueEnd := Position{start.Line, runeColumn(originalLine, strings.LastIndexFunc(originalLine, not(unicode.IsSpace)))}
+	column := -1
 	for i, char := range originalLine {
+		column++
 		if !encounteredEquals && unicode.IsSpace(char) {
 			continue
 		}
@@ -290,12 +336,12 @@ func ParseEqualLine(line string, originalLine string, start Position) (ass Assig
 
 		if encounteredEquals && !encounteredValue && !unicode.IsSpace(char) {
 			encounteredValue = true
-			valueStart.Column = i
+			valueStart.Column = column
 		}
 
 		if encounteredValue {
 			if char == '#' {
-				valueEnd.Column = strings.LastIndexFunc(originalLine[:i], not(unicode.IsSpace))
+				valueEnd.Column = runeColumn(originalLine, strings.LastIndexFunc(originalLine[:i], not(unicode.IsSpace)))
 				break
 			}
 			valueRaw += string(char)
@@ -354,7 +400,7 @@ func parseValue(raw string, valueStart Position) Value {
 			Custom: raw,
 		}
 	}
-	if boolean, err := parseBool(raw); err == nil {
+	if boolean, err := ParseBool(raw); err == nil {
 		return Value{
 			Kind: Bool,
 			Bool: boolean,
@@ -533,7 +579,8 @@ func decodeHexComponent(matches []string, component string, fallback uint8) uint
 	return uint8(decoded)
 }
 
-func parseBool(raw string) (bool, error) {
+// ParseBool parses a Hyprland boolean: true, yes, on or 1, and their opposites
+func ParseBool(raw string) (bool, error) {
 	switch strings.TrimSpace(raw) {
 	case "true", "yes", "on", "1":
 		return true, nil
@@ -555,6 +602,15 @@ func (s Section) WalkValues(f func(assignment *Assignment, v *Value)) {
 	}
 }
 
+func (s Section) WalkAssignments(f func(section *Section, assignment *Assignment)) {
+	for _, a := range s.Assignments {
+		f(&s, &a)
+	}
+	for _, sub := range s.Subsections {
+		sub.WalkAssignments(f)
+	}
+}
+
 func (s Section) WalkCustomVariables(f func(v *CustomVariable)) {
 	for _, v := range s.Variables {
 		f(&v)
@@ -32,3 +32,100 @@ func TestLowlevelParse(t *testing.T) {
 	// 	}
 	// }
 }
+
+func TestParseEmpty(t *testing.T) {
+	parsed, err := Parse("")
+	if err != nil {
+		t.Fatalf("Error while parsing: %s", err)
+	}
+
+	if len(parsed.Assignments) != 0 || len(parsed.Subsections) != 0 {
+		t.Errorf("Expected an empty document, got %#v", parsed)
+	}
+}
+
+func TestParseUnclosedSection(t *testing.T) {
+	parsed, err := Parse("general {")
+	if err != nil {
+		t.Fatalf("Error while parsing: %s", err)
+	}
+
+	if len(parsed.Subsections) != 1 || parsed.Subsections[0].Name != "general" {
+		t.Errorf("Expected the unclosed section to be in the document, got %#v", parsed.Subsections)
+	}
+}
+
+func TestParseUnclosedSectionWithAssignments(t *testing.T) {
+	parsed, err := Parse("general {\n  gaps_in = 5\n  ga")
+	if err != nil {
+		t.Fatalf("Error while parsing: %s", err)
+	}
+
+	if len(parsed.Subsections) != 1 || len(parsed.Subsections[0].Assignments) != 1 {
+		t.Fatalf("Expected the unclosed section and its assignment to be in the document, got %#v", parsed.Subsections)
+	}
+
+	if parsed.Subsections[0].End.Line != 2 {
+		t.Errorf("Expected the unclosed section to end with the document, got %#v", parsed.Subsections[0].End)
+	}
+}
+
+func TestParseStrayClosingBrace(t *testing.T) {
+	parsed, err := Parse("}\ngaps_in = 5")
+	if err != nil {
+		t.Fatalf("Error while parsing: %s", err)
+	}
+
+	if len(parsed.Assignments) != 1 {
+		t.Errorf("Expected the assignment after the stray brace to be parsed, got %#v", parsed.Assignments)
+	}
+}
+
+func TestParseHalfTypedKey(t *testing.T) {
+	parsed, err := Parse("ga")
+	if err != nil {
+		t.Fatalf("Error while parsing: %s", err)
+	}
+
+	if len(parsed.Assignments) != 0 {
+		t.Errorf("Expected no assignments, got %#v", parsed.Assignments)
+	}
+}
+
+func TestParseUnterminatedColor(t *testing.T) {
+	parsed, err := Parse("col.active_border = rgba(")
+	if err != nil {
+		t.Fatalf("Error while parsing: %s", err)
+	}
+
+	if len(parsed.Assignments) != 1 || parsed.Assignments[0].Value.Kind != String {
+		t.Errorf("Expected the unterminated color to be parsed as a string, got %#v", parsed.Assignments)
+	}
+}
+
+func TestParseStripsByteOrderMark(t *testing.T) {
+	parsed, err := Parse(ByteOrderMark + "gaps_in = 5\n")
+	if err != nil {
+		t.Fatalf("Error while parsing: %s", err)
+	}
+
+	if len(parsed.Assignments) != 1 || parsed.Assignments[0].Key != "gaps_in" {
+		t.Errorf("Expected a single assignment to gaps_in, got %#v", parsed.Assignments)
+	}
+}
+
+func TestParseColumnsCountCharacters(t *testing.T) {
+	parsed, err := Parse("general {\n  name = “café” # comment\n}\nlast = é")
+	if err != nil {
+		t.Fatalf("Error while parsing: %s", err)
+	}
+
+	value := parsed.Subsections[0].Assignments[0].Value
+	if value.Start.Column != 9 || value.End.Column != 14 {
+		t.Errorf("Expected value to span columns 9 to 14, got %d to %d", value.Start.Column, value.End.Column)
+	}
+
+	if parsed.End.Column != 8 {
+		t.Errorf("Expected document to end at column 8, got %d", parsed.End.Column)
+	}
+}
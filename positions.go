@@ -0,0 +1,189 @@
+package hyprls
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"go.lsp.dev/jsonrpc2"
+	"go.lsp.dev/protocol"
+)
+
+// positionConverter converts the characters of positions between the runes handlers count in, like the parser does,
+// and the UTF-16 code units clients count in, as per the LSP specification.
+// Each position is converted using the line it is on, in the document it belongs to.
+type positionConverter struct {
+	toUTF16 bool
+	// lines caches the lines of the documents positions were converted in
+	lines map[protocol.URI][]string
+}
+
+func newPositionConverter(toUTF16 bool) *positionConverter {
+	return &positionConverter{toUTF16: toUTF16, lines: make(map[protocol.URI][]string)}
+}
+
+// position converts the character of a position of the document uri.
+// Positions past the end of their line keep their distance to it.
+func (c *positionConverter) position(uri protocol.URI, position protocol.Position) protocol.Position {
+	lines, cached := c.lines[uri]
+	if !cached {
+		contents, _ := file(uri)
+		lines = strings.Split(contents, "\n")
+		c.lines[uri] = lines
+	}
+	if int(position.Line) >= len(lines) {
+		return position
+	}
+
+	var runes, units uint32
+	for _, r := range lines[position.Line] {
+		if c.toUTF16 && runes >= position.Character || !c.toUTF16 && units >= position.Character {
+			break
+		}
+		runes++
+		units++
+		if r >= 0x10000 {
+			// Characters outside of the basic multilingual plane are encoded as surrogate pairs
+			units++
+		}
+	}
+
+	if c.toUTF16 {
+		position.Character = units + position.Character - runes
+	} else {
+		position.Character = runes + position.Character - min(units, position.Character)
+	}
+	return position
+}
+
+// diagnostics converts the ranges of diagnostics of the document uri, and the ones of their related information, which can be in other documents
+func (c *positionConverter) diagnostics(uri protocol.URI, diagnostics []protocol.Diagnostic) []protocol.Diagnostic {
+	converted := make([]protocol.Diagnostic, 0, len(diagnostics))
+	for _, diagnostic := range diagnostics {
+		diagnostic.Range = c.rangeIn(uri, diagnostic.Range)
+		related := make([]protocol.DiagnosticRelatedInformation, 0, len(diagnostic.RelatedInformation))
+		for _, information := range diagnostic.RelatedInformation {
+			information.Location.Range = c.rangeIn(information.Location.URI, information.Location.Range)
+			related = append(related, information)
+		}
+		if len(related) > 0 {
+			diagnostic.RelatedInformation = related
+		}
+		converted = append(converted, diagnostic)
+	}
+	return converted
+}
+
+// range_ converts both ends of a range of the document uri
+func (c *positionConverter) rangeIn(uri protocol.URI, r protocol.Range) protocol.Range {
+	return protocol.Range{Start: c.position(uri, r.Start), End: c.position(uri, r.End)}
+}
+
+// walk converts the positions in value, a JSON value decoded without a specific type, as belonging to the document uri.
+// Objects that name another document hold positions of that document instead: locations and text document edits,
+// and the changes of workspace edits, which are keyed by document.
+func (c *positionConverter) walk(value interface{}, uri protocol.URI) {
+	switch value := value.(type) {
+	case []interface{}:
+		for _, item := range value {
+			c.walk(item, uri)
+		}
+	case map[string]interface{}:
+		line, isLine := value["line"].(float64)
+		character, isCharacter := value["character"].(float64)
+		if len(value) == 2 && isLine && isCharacter {
+			value["character"] = c.position(uri, protocol.Position{Line: uint32(line), Character: uint32(character)}).Character
+			return
+		}
+
+		if own, ok := value["uri"].(string); ok {
+			uri = protocol.URI(own)
+		}
+		if own := documentOf(value); own != "" {
+			uri = own
+		}
+		for key, field := range value {
+			if changes, ok := field.(map[string]interface{}); ok && key == "changes" {
+				for document, edits := range changes {
+					c.walk(edits, protocol.URI(document))
+				}
+				continue
+			}
+			c.walk(field, uri)
+		}
+	}
+}
+
+// documentOf returns the uri of the textDocument field of params, as in most requests, or an empty URI if there is none
+func documentOf(params interface{}) protocol.URI {
+	object, _ := params.(map[string]interface{})
+	document, _ := object["textDocument"].(map[string]interface{})
+	uri, _ := document["uri"].(string)
+	return protocol.URI(uri)
+}
+
+// positionMethods are the methods whose params or results hold positions. Requests and notifications of other methods are handled as they are.
+// Changes of textDocument/didChange are not converted either, since each one applies to the text the previous one left: see byteOffset.
+var positionMethods = map[string]bool{
+	protocol.MethodTextDocumentCodeAction:        true,
+	protocol.MethodTextDocumentColorPresentation: true,
+	protocol.MethodTextDocumentCompletion:        true,
+	protocol.MethodCompletionItemResolve:         true,
+	protocol.MethodTextDocumentDefinition:        true,
+	protocol.MethodTextDocumentDocumentColor:     true,
+	protocol.MethodTextDocumentDocumentHighlight: true,
+	protocol.MethodTextDocumentDocumentLink:      true,
+	protocol.MethodTextDocumentDocumentSymbol:    true,
+	protocol.MethodTextDocumentFormatting:        true,
+	protocol.MethodTextDocumentHover:             true,
+	protocol.MethodTextDocumentPrepareRename:     true,
+	protocol.MethodTextDocumentReferences:        true,
+	protocol.MethodTextDocumentRename:            true,
+	protocol.MethodTextDocumentSignatureHelp:     true,
+	protocol.MethodTextDocumentTypeDefinition:    true,
+	methodInlayHint:        true,
+	methodInlayHintResolve: true,
+	methodSelectionRange:   true,
+}
+
+// withUTF16Positions converts the positions of requests from the client to runes before handling them, and the ones of responses back to UTF-16, see positionConverter.
+// Only the requests of positionMethods are converted.
+func withUTF16Positions(next jsonrpc2.Handler) jsonrpc2.Handler {
+	return func(ctx context.Context, reply jsonrpc2.Replier, req jsonrpc2.Request) error {
+		if !positionMethods[req.Method()] || len(req.Params()) == 0 {
+			return next(ctx, reply, req)
+		}
+
+		var params interface{}
+		if err := json.Unmarshal(req.Params(), &params); err != nil {
+			return next(ctx, reply, req)
+		}
+		uri := documentOf(params)
+		newPositionConverter(false).walk(params, uri)
+
+		var converted jsonrpc2.Request
+		var err error
+		if call, isCall := req.(*jsonrpc2.Call); isCall {
+			converted, err = jsonrpc2.NewCall(call.ID(), req.Method(), params)
+		} else {
+			converted, err = jsonrpc2.NewNotification(req.Method(), params)
+		}
+		if err != nil {
+			return next(ctx, reply, req)
+		}
+
+		return next(ctx, func(ctx context.Context, result interface{}, err error) error {
+			if err != nil || result == nil {
+				return reply(ctx, result, err)
+			}
+
+			encoded, encodeErr := json.Marshal(result)
+			var decoded interface{}
+			if encodeErr != nil || json.Unmarshal(encoded, &decoded) != nil {
+				return reply(ctx, result, err)
+			}
+			newPositionConverter(true).walk(decoded, uri)
+			return reply(ctx, decoded, err)
+		}, converted)
+	}
+}
@@ -0,0 +1,119 @@
+package hyprls
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"go.lsp.dev/jsonrpc2"
+	"go.lsp.dev/protocol"
+)
+
+func TestPositionConverter(t *testing.T) {
+	uri := protocol.DocumentURI("file:///tmp/hyprls-test/positions.conf")
+	openedFiles[uri] = "# 🪟 windows\n$é = 5\n"
+	defer delete(openedFiles, uri)
+
+	for _, c := range []struct {
+		runes, utf16 protocol.Position
+	}{
+		{protocol.Position{Line: 0, Character: 2}, protocol.Position{Line: 0, Character: 2}},
+		{protocol.Position{Line: 0, Character: 4}, protocol.Position{Line: 0, Character: 5}},
+		{protocol.Position{Line: 0, Character: 13}, protocol.Position{Line: 0, Character: 14}},
+		{protocol.Position{Line: 1, Character: 2}, protocol.Position{Line: 1, Character: 2}},
+	} {
+		if converted := newPositionConverter(true).position(uri, c.runes); converted != c.utf16 {
+			t.Errorf("expected %v to be converted to %v, got %v", c.runes, c.utf16, converted)
+		}
+		if converted := newPositionConverter(false).position(uri, c.utf16); converted != c.runes {
+			t.Errorf("expected %v to be converted back to %v, got %v", c.utf16, c.runes, converted)
+		}
+	}
+}
+
+func TestPositionConverterWalk(t *testing.T) {
+	uri, other := protocol.DocumentURI("file:///tmp/hyprls-test/walk.conf"), protocol.DocumentURI("file:///tmp/hyprls-test/other.conf")
+	openedFiles[uri] = "🪟 = 1\n"
+	openedFiles[other] = "a = 1\n"
+	defer delete(openedFiles, uri)
+	defer delete(openedFiles, other)
+
+	var value interface{}
+	json.Unmarshal([]byte(`{
+		"range": {"start": {"line": 0, "character": 1}, "end": {"line": 0, "character": 3}},
+		"location": {"uri": "file:///tmp/hyprls-test/other.conf", "range": {"start": {"line": 0, "character": 1}, "end": {"line": 0, "character": 3}}},
+		"changes": {"file:///tmp/hyprls-test/other.conf": [{"range": {"start": {"line": 0, "character": 1}, "end": {"line": 0, "character": 3}}, "newText": "b"}]}
+	}`), &value)
+	newPositionConverter(true).walk(value, uri)
+
+	encoded, _ := json.Marshal(value)
+	var converted struct {
+		Range    protocol.Range
+		Location protocol.Location
+		Changes  map[protocol.DocumentURI][]protocol.TextEdit
+	}
+	json.Unmarshal(encoded, &converted)
+
+	if converted.Range.Start.Character != 2 || converted.Range.End.Character != 4 {
+		t.Errorf("expected the range of the request's document to be converted, got %v", converted.Range)
+	}
+	unchanged := protocol.Range{Start: protocol.Position{Character: 1}, End: protocol.Position{Character: 3}}
+	if !reflect.DeepEqual(converted.Location.Range, unchanged) || !reflect.DeepEqual(converted.Changes[other][0].Range, unchanged) {
+		t.Errorf("expected ranges of other documents to be converted using their own lines, got %v and %v", converted.Location.Range, converted.Changes[other])
+	}
+}
+
+func TestPositionConverterDiagnostics(t *testing.T) {
+	uri, other := protocol.DocumentURI("file:///tmp/hyprls-test/diagnostics.conf"), protocol.DocumentURI("file:///tmp/hyprls-test/related.conf")
+	openedFiles[uri] = "🪟 = 1\n"
+	openedFiles[other] = "🪟🪟 = 1\n"
+	defer delete(openedFiles, uri)
+	defer delete(openedFiles, other)
+
+	original := protocol.Range{Start: protocol.Position{Character: 1}, End: protocol.Position{Character: 2}}
+	diagnostics := []protocol.Diagnostic{{
+		Range: original,
+		RelatedInformation: []protocol.DiagnosticRelatedInformation{
+			{Location: protocol.Location{URI: uri, Range: original}},
+			{Location: protocol.Location{URI: other, Range: original}},
+		},
+	}}
+	converted := newPositionConverter(true).diagnostics(uri, diagnostics)
+
+	if converted[0].Range != (protocol.Range{Start: protocol.Position{Character: 2}, End: protocol.Position{Character: 3}}) {
+		t.Errorf("expected the diagnostic's range to be converted, got %v", converted[0].Range)
+	}
+	if related := converted[0].RelatedInformation[0].Location.Range; related != converted[0].Range {
+		t.Errorf("expected the related information's range to be converted, got %v", related)
+	}
+	if related := converted[0].RelatedInformation[1].Location.Range; related != (protocol.Range{Start: protocol.Position{Character: 2}, End: protocol.Position{Character: 4}}) {
+		t.Errorf("expected the related information's range to be converted using its own document, got %v", related)
+	}
+	if diagnostics[0].RelatedInformation[0].Location.Range != original {
+		t.Error("expected the given diagnostics not to be changed")
+	}
+}
+
+func TestWithUTF16PositionsOnlyConvertsPositionMethods(t *testing.T) {
+	uri := protocol.DocumentURI("file:///tmp/hyprls-test/methods.conf")
+	openedFiles[uri] = "🪟 = 1\n"
+	defer delete(openedFiles, uri)
+
+	params := `{"textDocument":{"uri":"file:///tmp/hyprls-test/methods.conf"},"position":{"line":0,"character":2}}`
+	for method, expected := range map[string]string{
+		protocol.MethodTextDocumentHover:   `{"position":{"character":1,"line":0},"textDocument":{"uri":"file:///tmp/hyprls-test/methods.conf"}}`,
+		protocol.MethodTextDocumentDidSave: params,
+	} {
+		request, _ := jsonrpc2.NewNotification(method, json.RawMessage(params))
+		var received jsonrpc2.Request
+		withUTF16Positions(func(ctx context.Context, reply jsonrpc2.Replier, req jsonrpc2.Request) error {
+			received = req
+			return nil
+		})(context.Background(), nil, request)
+
+		if string(received.Params()) != expected {
+			t.Errorf("expected the params of %s to be %s, got %s", method, expected, received.Params())
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package hyprls
+
+import (
+	"context"
+	"fmt"
+
+	"go.lsp.dev/protocol"
+)
+
+func (h Handler) References(ctx context.Context, params *protocol.ReferenceParams) ([]protocol.Location, error) {
+	contents, err := file(params.TextDocument.URI)
+	if err != nil {
+		return nil, fmt.Errorf("while reading file: %w", err)
+	}
+
+	variable, found := customVariableAt(contents, params.Position)
+	if !found {
+		return nil, nil
+	}
+
+	locations := make([]protocol.Location, 0)
+	for _, occurrence := range findCustomVariable(params.TextDocument.URI, variable.Name) {
+		if occurrence.Definition && !params.Context.IncludeDeclaration {
+			continue
+		}
+		locations = append(locations, occurrence.Location())
+	}
+	return locations, nil
+}
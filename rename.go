@@ -0,0 +1,59 @@
+package hyprls
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.lsp.dev/protocol"
+)
+
+var customVariableNamePattern = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+func (h Handler) PrepareRename(ctx context.Context, params *protocol.PrepareRenameParams) (*protocol.Range, error) {
+	contents, err := file(params.TextDocument.URI)
+	if err != nil {
+		return nil, fmt.Errorf("while reading file: %w", err)
+	}
+
+	occurrence, found := customVariableAt(contents, params.Position)
+	if !found {
+		return nil, errors.New("only custom $variables can be renamed")
+	}
+
+	return &occurrence.Range, nil
+}
+
+func (h Handler) Rename(ctx context.Context, params *protocol.RenameParams) (*protocol.WorkspaceEdit, error) {
+	contents, err := file(params.TextDocument.URI)
+	if err != nil {
+		return nil, fmt.Errorf("while reading file: %w", err)
+	}
+
+	renamed, found := customVariableAt(contents, params.Position)
+	if !found {
+		return nil, errors.New("only custom $variables can be renamed")
+	}
+
+	// Occurrence ranges don't include the $, so it's fine whether the new name starts with one or not
+	newName := strings.TrimPrefix(params.NewName, "$")
+	if !customVariableNamePattern.MatchString(newName) {
+		return nil, fmt.Errorf("%q is not a valid variable name", params.NewName)
+	}
+
+	if newName != renamed.Name && len(customVariableDefinitions(params.TextDocument.URI, newName)) > 0 {
+		return nil, fmt.Errorf("a variable named $%s already exists", newName)
+	}
+
+	changes := make(map[protocol.DocumentURI][]protocol.TextEdit)
+	for _, occurrence := range findCustomVariable(params.TextDocument.URI, renamed.Name) {
+		changes[occurrence.URI] = append(changes[occurrence.URI], protocol.TextEdit{
+			Range:   occurrence.Range,
+			NewText: newName,
+		})
+	}
+
+	return &protocol.WorkspaceEdit{Changes: changes}, nil
+}
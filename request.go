@@ -0,0 +1,100 @@
+package hyprls
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"go.lsp.dev/jsonrpc2"
+	"go.lsp.dev/protocol"
+)
+
+// Methods that are more recent than the protocol version implemented by go.lsp.dev/protocol
+const (
+	methodInlayHint        = "textDocument/inlayHint"
+	methodInlayHintResolve = "inlayHint/resolve"
+	methodSelectionRange   = "textDocument/selectionRange"
+)
+
+// Request handles requests for methods that are not known to go.lsp.dev/protocol
+func (h Handler) Request(ctx context.Context, method string, params interface{}) (interface{}, error) {
+	switch method {
+	case methodInlayHint:
+		var inlayHintParams InlayHintParams
+		if err := decodeParams(params, &inlayHintParams); err != nil {
+			return nil, err
+		}
+		return h.InlayHint(ctx, &inlayHintParams)
+	case methodInlayHintResolve:
+		var hint InlayHint
+		if err := decodeParams(params, &hint); err != nil {
+			return nil, err
+		}
+		return h.InlayHintResolve(ctx, &hint)
+	case methodSelectionRange:
+		var selectionRangeParams protocol.SelectionRangeParams
+		if err := decodeParams(params, &selectionRangeParams); err != nil {
+			return nil, err
+		}
+		return h.SelectionRange(ctx, &selectionRangeParams)
+	}
+	return nil, errors.New("unimplemented")
+}
+
+// decodeParams converts params, decoded as a generic JSON value, to the given struct
+func decodeParams(params interface{}, into interface{}) error {
+	encoded, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("while re-encoding params: %w", err)
+	}
+	if err := json.Unmarshal(encoded, into); err != nil {
+		return fmt.Errorf("while decoding params: %w", err)
+	}
+	return nil
+}
+
+type CompletionItemLabelDetails struct {
+	// Detail is shown right after the label, e.g. a human-readable name of the value
+	Detail string `json:"detail,omitempty"`
+	// Description is shown less prominently, after Detail
+	Description string `json:"description,omitempty"`
+}
+
+// CompletionItem adds the fields that are more recent than go.lsp.dev/protocol to protocol.CompletionItem
+type CompletionItem struct {
+	protocol.CompletionItem
+	LabelDetails *CompletionItemLabelDetails `json:"labelDetails,omitempty"`
+}
+
+type CompletionList struct {
+	IsIncomplete bool             `json:"isIncomplete"`
+	Items        []CompletionItem `json:"items"`
+}
+
+// withLabelDetails sends the label details of completion items, which can't be set on protocol.CompletionItem.
+// Completion handlers store them in the item's Data instead, they are moved to the labelDetails field before replying.
+func withLabelDetails(next jsonrpc2.Handler) jsonrpc2.Handler {
+	return func(ctx context.Context, reply jsonrpc2.Replier, req jsonrpc2.Request) error {
+		if req.Method() != protocol.MethodTextDocumentCompletion {
+			return next(ctx, reply, req)
+		}
+
+		return next(ctx, func(ctx context.Context, result interface{}, err error) error {
+			list, ok := result.(*protocol.CompletionList)
+			if !ok || list == nil {
+				return reply(ctx, result, err)
+			}
+
+			withDetails := CompletionList{IsIncomplete: list.IsIncomplete, Items: make([]CompletionItem, 0, len(list.Items))}
+			for _, item := range list.Items {
+				details, ok := item.Data.(*CompletionItemLabelDetails)
+				if ok {
+					item.Data = nil
+				}
+				withDetails.Items = append(withDetails.Items, CompletionItem{CompletionItem: item, LabelDetails: details})
+			}
+			return reply(ctx, withDetails, err)
+		}, req)
+	}
+}
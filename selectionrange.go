@@ -0,0 +1,117 @@
+package hyprls
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ewen-lbh/hyprls/parser"
+	"go.lsp.dev/protocol"
+)
+
+func (h Handler) SelectionRange(ctx context.Context, params *protocol.SelectionRangeParams) ([]protocol.SelectionRange, error) {
+	contents, err := file(params.TextDocument.URI)
+	if err != nil {
+		return nil, fmt.Errorf("while reading file: %w", err)
+	}
+
+	document, err := parser.Parse(contents)
+	if err != nil {
+		return nil, fmt.Errorf("while parsing: %w", err)
+	}
+
+	ranges := make([]protocol.SelectionRange, 0, len(params.Positions))
+	for _, position := range params.Positions {
+		ranges = append(ranges, selectionRange(contents, document, position))
+	}
+	return ranges, nil
+}
+
+// selectionRange returns the ranges the selection expands to from the given position, innermost first:
+// the word under the cursor, the line, then for each enclosing section the contents of its braces and the whole section, and finally the document.
+func selectionRange(contents string, document parser.Section, position protocol.Position) protocol.SelectionRange {
+	lines := strings.Split(contents, "\n")
+	expansions := make([]protocol.Range, 0)
+	if int(position.Line) < len(lines) {
+		line := []rune(lines[position.Line])
+		isPartOfWord := func(r rune) bool {
+			return !strings.ContainsRune(" \t=,{}", r)
+		}
+
+		start, end := min(int(position.Character), len(line)), min(int(position.Character), len(line))
+		for start > 0 && isPartOfWord(line[start-1]) {
+			start--
+		}
+		for end < len(line) && isPartOfWord(line[end]) {
+			end++
+		}
+		if start < end {
+			expansions = append(expansions, lineRange(position.Line, start, end))
+		}
+
+		trimmed := strings.TrimLeft(string(line), " \t")
+		lineStart := len(line) - utf8.RuneCountInString(trimmed)
+		lineEnd := lineStart + utf8.RuneCountInString(strings.TrimRight(trimmed, " \t"))
+		expansions = append(expansions, lineRange(position.Line, lineStart, lineEnd))
+	}
+
+	enclosing := make([]parser.Section, 0)
+	var walk func(section parser.Section)
+	walk = func(section parser.Section) {
+		for _, subsection := range section.Subsections {
+			whole := protocol.Range{
+				Start: sectionNameRange(lines[subsection.Start.Line], subsection).Start,
+				End:   protocol.Position{Line: uint32(subsection.End.Line), Character: uint32(subsection.End.Column + 1)},
+			}
+			if within(whole, position) {
+				enclosing = append(enclosing, subsection)
+				walk(subsection)
+				return
+			}
+		}
+	}
+	walk(document)
+
+	for i := len(enclosing) - 1; i >= 0; i-- {
+		section := enclosing[i]
+		end := protocol.Position{Line: uint32(section.End.Line), Character: uint32(section.End.Column + 1)}
+		expansions = append(expansions,
+			protocol.Range{Start: sectionOpeningBrace(lines[section.Start.Line], section), End: end},
+			protocol.Range{Start: sectionNameRange(lines[section.Start.Line], section).Start, End: end},
+		)
+	}
+
+	lastLine := len(lines) - 1
+	expansions = append(expansions, lineRange(uint32(lastLine), 0, utf8.RuneCountInString(lines[lastLine])))
+	expansions[len(expansions)-1].Start = protocol.Position{}
+
+	// Each range is the parent of the previous one, ranges that don't grow are skipped
+	var selection *protocol.SelectionRange
+	for i := len(expansions) - 1; i >= 0; i-- {
+		if selection != nil && selection.Range == expansions[i] {
+			continue
+		}
+		selection = &protocol.SelectionRange{Range: expansions[i], Parent: selection}
+	}
+	return *selection
+}
+
+// lineRange returns the range of the given line between the columns start and end
+func lineRange(line uint32, start int, end int) protocol.Range {
+	return protocol.Range{
+		Start: protocol.Position{Line: line, Character: uint32(start)},
+		End:   protocol.Position{Line: line, Character: uint32(end)},
+	}
+}
+
+// sectionOpeningBrace returns the position of the section's opening brace, line being the line the section starts on
+func sectionOpeningBrace(line string, section parser.Section) protocol.Position {
+	name := sectionNameRange(line, section)
+	header := []rune(line)
+	brace := int(name.End.Character)
+	for brace < len(header)-1 && header[brace] != '{' {
+		brace++
+	}
+	return protocol.Position{Line: name.Start.Line, Character: uint32(brace)}
+}
@@ -0,0 +1,41 @@
+package hyprls
+
+import (
+	"testing"
+
+	"github.com/ewen-lbh/hyprls/parser"
+	"go.lsp.dev/protocol"
+)
+
+func TestSelectionRange(t *testing.T) {
+	contents := "decoration {\n  blur {\n    size = 3\n  }\n}\n"
+	document, err := parser.Parse(contents)
+	if err != nil {
+		t.Fatalf("while parsing: %s", err)
+	}
+
+	expected := []protocol.Range{
+		lineRange(2, 4, 8),
+		lineRange(2, 4, 12),
+		{Start: protocol.Position{Line: 1, Character: 7}, End: protocol.Position{Line: 3, Character: 3}},
+		{Start: protocol.Position{Line: 1, Character: 2}, End: protocol.Position{Line: 3, Character: 3}},
+		{Start: protocol.Position{Line: 0, Character: 11}, End: protocol.Position{Line: 4, Character: 1}},
+		{Start: protocol.Position{Line: 0, Character: 0}, End: protocol.Position{Line: 4, Character: 1}},
+		{Start: protocol.Position{Line: 0, Character: 0}, End: protocol.Position{Line: 5, Character: 0}},
+	}
+
+	selection := selectionRange(contents, document, protocol.Position{Line: 2, Character: 5})
+	current := &selection
+	for i, rang := range expected {
+		if current == nil {
+			t.Fatalf("expected %d expansions, got %d", len(expected), i)
+		}
+		if current.Range != rang {
+			t.Errorf("expansion %d: expected %v, got %v", i, rang, current.Range)
+		}
+		current = current.Parent
+	}
+	if current != nil {
+		t.Errorf("expected no more expansions, got %v", current.Range)
+	}
+}
@@ -0,0 +1,65 @@
+package hyprls
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	parser_data "github.com/ewen-lbh/hyprls/parser/data"
+	"go.lsp.dev/protocol"
+)
+
+func (h Handler) SignatureHelp(ctx context.Context, params *protocol.SignatureHelpParams) (*protocol.SignatureHelp, error) {
+	line, err := currentLine(params.TextDocument.URI, params.Position)
+	if err != nil {
+		return nil, nil
+	}
+
+	return keywordSignatureHelp(line, params.Position), nil
+}
+
+// keywordSignatureHelp returns the parameters of the keyword assigned on the line, e.g. bind = mods, key, dispatcher, args,
+// with the parameter the cursor is on as the active one. It returns nil if the cursor is not in the value of a keyword that takes parameters.
+func keywordSignatureHelp(line string, position protocol.Position) *protocol.SignatureHelp {
+	if !cursorIsInValue(line, position) {
+		return nil
+	}
+
+	key, _, _ := strings.Cut(line, "=")
+	keyword, found := parser_data.FindKeyword(strings.TrimSpace(key))
+	if !found || len(keyword.Parameters) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(keyword.Parameters))
+	parameters := make([]protocol.ParameterInformation, 0, len(keyword.Parameters))
+	for _, parameter := range keyword.Parameters {
+		names = append(names, parameter.Name)
+		parameters = append(parameters, protocol.ParameterInformation{
+			Label:         parameter.Name,
+			Documentation: parameter.Description,
+		})
+	}
+
+	field, ok := argumentIndex(line, position)
+	if !ok {
+		return nil
+	}
+	// The last parameter takes the rest of the line, commas included, e.g. the filters of windowrulev2
+	active := min(field, len(parameters)-1)
+
+	return &protocol.SignatureHelp{
+		Signatures: []protocol.SignatureInformation{
+			{
+				Label: fmt.Sprintf("%s = %s", keyword.Name, strings.Join(names, ", ")),
+				Documentation: protocol.MarkupContent{
+					Kind:  protocol.Markdown,
+					Value: keyword.Description,
+				},
+				Parameters:      parameters,
+				ActiveParameter: uint32(active),
+			},
+		},
+		ActiveParameter: uint32(active),
+	}
+}
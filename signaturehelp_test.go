@@ -0,0 +1,38 @@
+package hyprls
+
+import (
+	"testing"
+
+	"go.lsp.dev/protocol"
+)
+
+func TestKeywordSignatureHelp(t *testing.T) {
+	for _, c := range []struct {
+		line      string
+		character uint32
+		active    string
+	}{
+		{"bind = ", 7, "mods"},
+		{"bind = SUPER, Q", 15, "key"},
+		{"bindel = , XF86AudioRaiseVolume, exec, ", 39, "args"},
+		{"bind = SUPER, Q, exec, notify-send a, b", 39, "args"},
+		{"bezier = overshot, 0.05, 0.9, ", 30, "x1"},
+		{"monitor = DP-1, preferred, ", 27, "position"},
+		{"windowrulev2 = float, class:^(kitty)$, title:", 46, "window"},
+	} {
+		help := keywordSignatureHelp(c.line, protocol.Position{Character: c.character})
+		if help == nil || len(help.Signatures) != 1 {
+			t.Errorf("expected a signature for %q, got %#v", c.line, help)
+			continue
+		}
+		if active := help.Signatures[0].Parameters[help.ActiveParameter].Label; active != c.active {
+			t.Errorf("expected %s to be active in %q, got %s", c.active, c.line, active)
+		}
+	}
+
+	for _, line := range []string{"exec-once = waybar", "general:layout = dwindle", "bind"} {
+		if help := keywordSignatureHelp(line, protocol.Position{Character: uint32(len(line))}); help != nil {
+			t.Errorf("expected no signature for %q, got %#v", line, help)
+		}
+	}
+}
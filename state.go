@@ -2,6 +2,7 @@ package hyprls
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"strings"
 
@@ -15,6 +16,19 @@ var logger *zap.Logger
 
 var openedFiles = make(map[protocol.URI]string)
 
+// diskFiles caches the contents of the files read from disk that are not opened in the editor, see file
+var diskFiles = make(map[protocol.URI]string)
+
+// resetState forgets about the previous client, so that a new one starts with the same state as the first one did
+func resetState(base *zap.Logger) {
+	logger = base
+	openedFiles = make(map[protocol.URI]string)
+	options = defaultOptions
+	clientCanWatchFiles = false
+	watchedPatterns = make(map[string]bool)
+	diskFiles = make(map[protocol.URI]string)
+}
+
 type state struct {
 }
 
@@ -53,13 +67,44 @@ func currentAssignment(root parser.Section, position protocol.Position) *parser_
 
 	for _, assignment := range root.Assignments {
 		if assignment.Position.Line == int(position.Line) {
-			return parser_data.FindVariableDefinitionInSection(root.Name, assignment.Key)
+			return assignedVariableDefinition(root.Name, assignment.Key)
 		}
 	}
 
 	return nil
 }
 
+// assignedVariableDefinition returns the definition of the variable assigned with key in the section named sectionName.
+// The key can also be the path to a variable of a subsection, e.g. blur:enabled in decoration,
+// or its full path, e.g. general:layout, which is how variables are assigned outside of their section.
+func assignedVariableDefinition(sectionName, key string) *parser_data.VariableDefinition {
+	if definition := parser_data.FindVariableDefinitionInSection(sectionName, key); definition != nil || !strings.Contains(key, ":") {
+		return definition
+	}
+
+	if definition := nestedVariableDefinition(sectionName, key); definition != nil {
+		return definition
+	}
+
+	section, variable, _ := strings.Cut(key, ":")
+	return nestedVariableDefinition(section, variable)
+}
+
+// nestedVariableDefinition looks up the variable among the ones of the section named sectionName and of its subsections, see parser_data.SectionDefinition.AllVariables
+func nestedVariableDefinition(sectionName, name string) *parser_data.VariableDefinition {
+	section := parser_data.FindSectionDefinitionByName(sectionName)
+	if section == nil {
+		return nil
+	}
+
+	for _, variable := range section.AllVariables() {
+		if variable.Name == name {
+			return &variable
+		}
+	}
+	return nil
+}
+
 func within(rang protocol.Range, position protocol.Position) bool {
 	if position.Line < rang.Start.Line || position.Line > rang.End.Line {
 		return false
@@ -81,13 +126,18 @@ func file(uri protocol.URI) (string, error) {
 		return contents, nil
 	}
 
+	if contents, ok := diskFiles[uri]; ok {
+		return contents, nil
+	}
+
+	// Cached until the client tells us the file changed on disk, see DidChangeWatchedFiles
 	contents, err := os.ReadFile(uri.Filename())
 	if err != nil {
 		return "", err
 	}
 
-	openedFiles[uri] = string(contents)
-	return string(contents), nil
+	diskFiles[uri] = strings.TrimPrefix(string(contents), parser.ByteOrderMark)
+	return diskFiles[uri], nil
 }
 
 func currentLine(uri protocol.URI, position protocol.Position) (string, error) {
@@ -97,5 +147,8 @@ func currentLine(uri protocol.URI, position protocol.Position) (string, error) {
 	}
 
 	lines := strings.Split(contents, "\n")
+	if int(position.Line) >= len(lines) {
+		return "", fmt.Errorf("line %d is out of the document's %d lines", position.Line, len(lines))
+	}
 	return lines[position.Line], nil
 }
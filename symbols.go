@@ -3,7 +3,9 @@ package hyprls
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	"github.com/ewen-lbh/hyprls/config"
 	"github.com/ewen-lbh/hyprls/parser"
 	"go.lsp.dev/protocol"
 )
@@ -17,9 +19,53 @@ func (h Handler) DocumentSymbol(ctx context.Context, params *protocol.DocumentSy
 	for _, symb := range gatherAllSymbols(document) {
 		symbols = append(symbols, &symb)
 	}
+
+	contents, err := file(params.TextDocument.URI)
+	if err != nil {
+		return nil, fmt.Errorf("while reading file: %w", err)
+	}
+	for _, symb := range submapSymbols(contents) {
+		symbols = append(symbols, &symb)
+	}
 	return symbols, nil
 }
 
+// submapSymbols returns a symbol for each submap, with the binds it defines as children
+func submapSymbols(contents string) []protocol.DocumentSymbol {
+	binds := config.Binds(contents)
+	symbols := make([]protocol.DocumentSymbol, 0)
+	for _, block := range submapBlocks(contents) {
+		children := make([]protocol.DocumentSymbol, 0)
+		for _, bind := range binds {
+			if bind.Submap != block.Name || !within(block.Range, bind.Range.Start.LSP()) {
+				continue
+			}
+
+			detail := make([]string, 0)
+			for _, argument := range bind.Arguments[2:] {
+				detail = append(detail, argument.Value)
+			}
+			children = append(children, protocol.DocumentSymbol{
+				Name:           bind.Combination(),
+				Kind:           protocol.SymbolKindKey,
+				Detail:         strings.Join(detail, ", "),
+				Range:          lspRange(bind.Range),
+				SelectionRange: lspRange(bind.Range),
+			})
+		}
+
+		symbols = append(symbols, protocol.DocumentSymbol{
+			Name:           block.Name,
+			Kind:           protocol.SymbolKindNamespace,
+			Detail:         "submap",
+			Range:          block.Range,
+			SelectionRange: block.NameRange,
+			Children:       children,
+		})
+	}
+	return symbols
+}
+
 func gatherAllSymbols(root parser.Section) []protocol.DocumentSymbol {
 	symbols := make([]protocol.DocumentSymbol, 0)
 	for _, variable := range root.Assignments {
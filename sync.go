@@ -2,29 +2,117 @@ package hyprls
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"strings"
 
+	"go.lsp.dev/jsonrpc2"
 	"go.lsp.dev/protocol"
 	"go.uber.org/zap"
 )
 
+// contentChange is a protocol.TextDocumentContentChangeEvent whose range is nil when the change replaces the whole document.
+// protocol.TextDocumentContentChangeEvent can't tell such a change from an insertion at the start of the document.
+type contentChange struct {
+	Range *protocol.Range `json:"range,omitempty"`
+	Text  string          `json:"text"`
+}
+
+type didChangeParams struct {
+	TextDocument   protocol.VersionedTextDocumentIdentifier `json:"textDocument"`
+	ContentChanges []contentChange                          `json:"contentChanges"`
+}
 
 func (h Handler) DidChange(ctx context.Context, params *protocol.DidChangeTextDocumentParams) error {
+	changes := make([]contentChange, 0, len(params.ContentChanges))
+	for _, change := range params.ContentChanges {
+		changes = append(changes, contentChange{Range: &change.Range, Text: change.Text})
+	}
+	return h.didChange(ctx, &didChangeParams{TextDocument: params.TextDocument, ContentChanges: changes})
+}
+
+func (h Handler) didChange(ctx context.Context, params *didChangeParams) error {
 	logger.Debug("LSP:DidChange", zap.Any("params", params))
-	openedFiles[params.TextDocument.URI] = params.ContentChanges[len(params.ContentChanges)-1].Text
+	openedFiles[params.TextDocument.URI] = applyContentChanges(openedFiles[params.TextDocument.URI], params.ContentChanges)
+	h.publishDiagnostics(ctx, params.TextDocument.URI)
+	// The change can source other files
+	h.watchIncludeGraph(ctx, params.TextDocument.URI)
 	return nil
 }
 
+// withFullContentChanges handles textDocument/didChange notifications instead of protocol.ServerHandler,
+// so that changes replacing the whole document are decoded without a range, see contentChange.
+func withFullContentChanges(handler Handler, next jsonrpc2.Handler) jsonrpc2.Handler {
+	return func(ctx context.Context, reply jsonrpc2.Replier, req jsonrpc2.Request) error {
+		if req.Method() != protocol.MethodTextDocumentDidChange {
+			return next(ctx, reply, req)
+		}
+
+		var params didChangeParams
+		if err := json.Unmarshal(req.Params(), &params); err != nil {
+			return reply(ctx, nil, fmt.Errorf("%s: %w", jsonrpc2.ErrParse, err))
+		}
+		return reply(ctx, nil, handler.didChange(ctx, &params))
+	}
+}
+
 func (h Handler) DidClose(ctx context.Context, params *protocol.DidCloseTextDocumentParams) error {
 	delete(openedFiles, params.TextDocument.URI)
+	// The file may have been saved while it was opened
+	delete(diskFiles, params.TextDocument.URI)
 	return nil
 }
 
 func (h Handler) DidOpen(ctx context.Context, params *protocol.DidOpenTextDocumentParams) error {
-	file(params.TextDocument.URI)
+	openedFiles[params.TextDocument.URI] = params.TextDocument.Text
+	h.publishDiagnostics(ctx, params.TextDocument.URI)
+	h.publishIncludedFilesDiagnostics(ctx, params.TextDocument.URI)
+	h.watchIncludeGraph(ctx, params.TextDocument.URI)
 	return nil
 }
 
 func (h Handler) DidSave(ctx context.Context, params *protocol.DidSaveTextDocumentParams) error {
 	return errors.New("unimplemented")
 }
+
+// applyContentChanges returns text with the changes applied in order.
+// Changes without a range replace the whole text.
+func applyContentChanges(text string, changes []contentChange) string {
+	for _, change := range changes {
+		if change.Range == nil {
+			text = change.Text
+			continue
+		}
+		start := byteOffset(text, change.Range.Start)
+		end := max(start, byteOffset(text, change.Range.End))
+		text = text[:start] + change.Text + text[end:]
+	}
+	return text
+}
+
+// byteOffset returns the offset in text of the given position, whose character is counted in UTF-16 code units as per the LSP specification.
+// Positions past the end of a line or of the text are clamped.
+func byteOffset(text string, position protocol.Position) int {
+	offset := 0
+	for line := uint32(0); line < position.Line; line++ {
+		newline := strings.IndexByte(text[offset:], '\n')
+		if newline == -1 {
+			return len(text)
+		}
+		offset += newline + 1
+	}
+
+	units := uint32(0)
+	for i, r := range text[offset:] {
+		if units >= position.Character || r == '\n' {
+			return offset + i
+		}
+		units++
+		if r >= 0x10000 {
+			// Characters outside of the basic multilingual plane are encoded as surrogate pairs
+			units++
+		}
+	}
+	return len(text)
+}
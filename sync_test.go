@@ -0,0 +1,63 @@
+package hyprls
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"go.lsp.dev/protocol"
+)
+
+func TestApplyIncrementalChanges(t *testing.T) {
+	original := "$gap = 5\ngeneral {\n  gaps_in = $gap\n}\n"
+	changes := []contentChange{
+		// Rename $gap to $gaps in its definition
+		{Range: &protocol.Range{Start: protocol.Position{Line: 0, Character: 4}, End: protocol.Position{Line: 0, Character: 4}}, Text: "s"},
+		// Insert a line with a multi-byte character before the closing brace
+		{Range: &protocol.Range{Start: protocol.Position{Line: 3, Character: 0}, End: protocol.Position{Line: 3, Character: 0}}, Text: "  # 🪟 gaps\n  border_size = -1\n"},
+		// Replace the now undefined $gap by a literal
+		{Range: &protocol.Range{Start: protocol.Position{Line: 2, Character: 12}, End: protocol.Position{Line: 2, Character: 16}}, Text: "10"},
+		// Edit after the emoji, which takes two UTF-16 code units
+		{Range: &protocol.Range{Start: protocol.Position{Line: 3, Character: 7}, End: protocol.Position{Line: 3, Character: 8}}, Text: "w"},
+	}
+	expected := "$gaps = 5\ngeneral {\n  gaps_in = 10\n  # 🪟 waps\n  border_size = -1\n}\n"
+
+	incremental := applyContentChanges(original, changes)
+	if incremental != expected {
+		t.Fatalf("expected\n%s\ngot\n%s", expected, incremental)
+	}
+
+	full := applyContentChanges(original, []contentChange{{Text: expected}})
+	if full != expected {
+		t.Fatalf("expected a change without range to replace the whole document, got\n%s", full)
+	}
+
+	uri := protocol.DocumentURI("file:///tmp/hyprls-test/incremental.conf")
+	openedFiles[uri] = incremental
+	incrementalDiagnostics := diagnose(uri, incremental)
+	openedFiles[uri] = full
+	fullDiagnostics := diagnose(uri, full)
+	delete(openedFiles, uri)
+
+	if len(incrementalDiagnostics) == 0 || !reflect.DeepEqual(incrementalDiagnostics, fullDiagnostics) {
+		t.Fatalf("expected the same diagnostics as a full change, got %v and %v", incrementalDiagnostics, fullDiagnostics)
+	}
+}
+
+func TestApplyChangesAfterFullChange(t *testing.T) {
+	var params didChangeParams
+	err := json.Unmarshal([]byte(`{
+		"textDocument": {"uri": "file:///tmp/hyprls-test/full.conf", "version": 2},
+		"contentChanges": [
+			{"text": "general {\n}\n"},
+			{"range": {"start": {"line": 1, "character": 0}, "end": {"line": 1, "character": 0}}, "text": "  gaps_in = 5\n"}
+		]
+	}`), &params)
+	if err != nil {
+		t.Fatalf("while decoding params: %s", err)
+	}
+
+	if updated := applyContentChanges("decoration {\n}\n", params.ContentChanges); updated != "general {\n  gaps_in = 5\n}\n" {
+		t.Errorf("expected the ranged change to apply to the replaced document, got %q", updated)
+	}
+}
@@ -0,0 +1,26 @@
+package hyprls
+
+import (
+	"context"
+	"fmt"
+
+	"go.lsp.dev/protocol"
+)
+
+func (h Handler) TypeDefinition(ctx context.Context, params *protocol.TypeDefinitionParams) ([]protocol.Location, error) {
+	contents, err := file(params.TextDocument.URI)
+	if err != nil {
+		return nil, fmt.Errorf("while reading file: %w", err)
+	}
+
+	variable, found := customVariableAt(contents, params.Position)
+	if !found {
+		return []protocol.Location{}, nil
+	}
+
+	definition, _, found := customVariableTypeDefinition(params.TextDocument.URI, variable.Name)
+	if !found {
+		return []protocol.Location{}, nil
+	}
+	return []protocol.Location{definition.Location()}, nil
+}
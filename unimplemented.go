@@ -7,10 +7,6 @@ import (
 	"go.lsp.dev/protocol"
 )
 
-func (h Handler) Definition(ctx context.Context, params *protocol.DefinitionParams) ([]protocol.Location, error) {
-	return []protocol.Location{}, errors.New("unimplemented")
-}
-
 func (h Handler) WorkDoneProgressCancel(ctx context.Context, params *protocol.WorkDoneProgressCancelParams) error {
 	return errors.New("unimplemented")
 }
@@ -23,10 +19,6 @@ func (h Handler) SetTrace(ctx context.Context, params *protocol.SetTraceParams)
 	return errors.New("unimplemented")
 }
 
-func (h Handler) CodeAction(ctx context.Context, params *protocol.CodeActionParams) ([]protocol.CodeAction, error) {
-	return nil, errors.New("unimplemented")
-}
-
 func (h Handler) CodeLens(ctx context.Context, params *protocol.CodeLensParams) ([]protocol.CodeLens, error) {
 	return nil, errors.New("unimplemented")
 }
@@ -43,22 +35,10 @@ func (h Handler) DidChangeConfiguration(ctx context.Context, params *protocol.Di
 	return errors.New("unimplemented")
 }
 
-func (h Handler) DidChangeWatchedFiles(ctx context.Context, params *protocol.DidChangeWatchedFilesParams) error {
-	return errors.New("unimplemented")
-}
-
 func (h Handler) DidChangeWorkspaceFolders(ctx context.Context, params *protocol.DidChangeWorkspaceFoldersParams) error {
 	return errors.New("unimplemented")
 }
 
-func (h Handler) DocumentHighlight(ctx context.Context, params *protocol.DocumentHighlightParams) ([]protocol.DocumentHighlight, error) {
-	return nil, errors.New("unimplemented")
-}
-
-func (h Handler) DocumentLink(ctx context.Context, params *protocol.DocumentLinkParams) ([]protocol.DocumentLink, error) {
-	return nil, errors.New("unimplemented")
-}
-
 func (h Handler) DocumentLinkResolve(ctx context.Context, params *protocol.DocumentLink) (*protocol.DocumentLink, error) {
 	return nil, errors.New("unimplemented")
 }
@@ -71,10 +51,6 @@ func (h Handler) FoldingRanges(ctx context.Context, params *protocol.FoldingRang
 	return nil, errors.New("unimplemented")
 }
 
-func (h Handler) Formatting(ctx context.Context, params *protocol.DocumentFormattingParams) ([]protocol.TextEdit, error) {
-	return nil, errors.New("unimplemented")
-}
-
 func (h Handler) Implementation(ctx context.Context, params *protocol.ImplementationParams) ([]protocol.Location, error) {
 	return nil, errors.New("unimplemented")
 }
@@ -83,34 +59,14 @@ func (h Handler) OnTypeFormatting(ctx context.Context, params *protocol.Document
 	return nil, errors.New("unimplemented")
 }
 
-func (h Handler) PrepareRename(ctx context.Context, params *protocol.PrepareRenameParams) (*protocol.Range, error) {
-	return nil, errors.New("unimplemented")
-}
-
 func (h Handler) RangeFormatting(ctx context.Context, params *protocol.DocumentRangeFormattingParams) ([]protocol.TextEdit, error) {
 	return nil, errors.New("unimplemented")
 }
 
-func (h Handler) References(ctx context.Context, params *protocol.ReferenceParams) ([]protocol.Location, error) {
-	return nil, errors.New("unimplemented")
-}
-
-func (h Handler) Rename(ctx context.Context, params *protocol.RenameParams) (*protocol.WorkspaceEdit, error) {
-	return nil, errors.New("unimplemented")
-}
-
-func (h Handler) SignatureHelp(ctx context.Context, params *protocol.SignatureHelpParams) (*protocol.SignatureHelp, error) {
-	return nil, errors.New("unimplemented")
-}
-
 func (h Handler) Symbols(ctx context.Context, params *protocol.WorkspaceSymbolParams) ([]protocol.SymbolInformation, error) {
 	return nil, errors.New("unimplemented")
 }
 
-func (h Handler) TypeDefinition(ctx context.Context, params *protocol.TypeDefinitionParams) ([]protocol.Location, error) {
-	return nil, errors.New("unimplemented")
-}
-
 func (h Handler) WillSave(ctx context.Context, params *protocol.WillSaveTextDocumentParams) error {
 	return errors.New("unimplemented")
 }
@@ -186,7 +142,3 @@ func (h Handler) LinkedEditingRange(ctx context.Context, params *protocol.Linked
 func (h Handler) Moniker(ctx context.Context, params *protocol.MonikerParams) ([]protocol.Moniker, error) {
 	return nil, errors.New("unimplemented")
 }
-
-func (h Handler) Request(ctx context.Context, method string, params interface{}) (interface{}, error) {
-	return nil, errors.New("unimplemented")
-}
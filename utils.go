@@ -1,6 +1,13 @@
 package hyprls
 
-import "go.lsp.dev/protocol"
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ewen-lbh/hyprls/config"
+	"github.com/ewen-lbh/hyprls/parser"
+	"go.lsp.dev/protocol"
+)
 
 func collapsedRange(position protocol.Position) protocol.Range {
 	return protocol.Range{
@@ -9,3 +16,62 @@ func collapsedRange(position protocol.Position) protocol.Range {
 	}
 }
 
+// assignmentKeyRange returns the range of the assignment's key
+func assignmentKeyRange(assignment parser.Assignment) protocol.Range {
+	return protocol.Range{
+		Start: assignment.Position.LSP(),
+		End: protocol.Position{
+			Line:      uint32(assignment.Position.Line),
+			Character: uint32(assignment.Position.Column + utf8.RuneCountInString(assignment.Key)),
+		},
+	}
+}
+
+type argument struct {
+	// Value is the argument, without surrounding whitespace
+	Value string
+	Range protocol.Range
+}
+
+// lineArguments returns the comma-separated arguments of a key = arg1, arg2, ... line, comment excluded
+func lineArguments(line string, lineNumber int) []argument {
+	arguments := make([]argument, 0)
+	for _, arg := range config.LineArguments(line, lineNumber) {
+		arguments = append(arguments, argument{Value: arg.Value, Range: lspRange(arg.Range)})
+	}
+	return arguments
+}
+
+// lspRange converts a range of the config package, whose columns are runes like the ones handlers use
+func lspRange(r config.Range) protocol.Range {
+	return protocol.Range{
+		Start: r.Start.LSP(),
+		End:   r.End.LSP(),
+	}
+}
+
+// argumentIndex returns the index of the comma-separated argument of the key = arg1, arg2, ... line the cursor is in,
+// e.g. 2 when completing the dispatcher of bind = SUPER, Q, ex. Arguments are split the same way as config.LineArguments does.
+// ok is false if the cursor is not after the =, or is in a comment.
+func argumentIndex(line string, position protocol.Position) (index int, ok bool) {
+	beforeCursor := textBeforeCursor(line, position)
+	if config.StripComment(beforeCursor) != beforeCursor {
+		return 0, false
+	}
+	_, value, found := strings.Cut(beforeCursor, "=")
+	if !found {
+		return 0, false
+	}
+	return len(config.SplitArguments(value)) - 1, true
+}
+
+// runeColumn returns the column of the byte at offset in line. Like the parser, handlers count columns in runes, see withUTF16Positions.
+func runeColumn(line string, offset int) int {
+	return utf8.RuneCountInString(line[:offset])
+}
+
+// textBeforeCursor returns the part of the line that is before the cursor
+func textBeforeCursor(line string, position protocol.Position) string {
+	runes := []rune(line)
+	return string(runes[:min(int(position.Character), len(runes))])
+}
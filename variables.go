@@ -0,0 +1,137 @@
+package hyprls
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/ewen-lbh/hyprls/config"
+	"github.com/ewen-lbh/hyprls/parser"
+	"go.lsp.dev/protocol"
+)
+
+var customVariableReferencePattern = regexp.MustCompile(`\$([A-Za-z0-9_]+)`)
+
+type customVariableOccurrence struct {
+	Name string
+	// Range is the range of the variable's name, not including the $
+	Range protocol.Range
+	// Definition is true if this occurrence is the $name = ... line defining the variable
+	Definition bool
+}
+
+// customVariableOccurrences returns every definition and usage of custom variables in the file
+func customVariableOccurrences(contents string) []customVariableOccurrence {
+	occurrences := make([]customVariableOccurrence, 0)
+	for _, occurrence := range config.CustomVariableOccurrences(contents) {
+		occurrences = append(occurrences, customVariableOccurrence{
+			Name:       occurrence.Name,
+			Range:      lspRange(occurrence.Range),
+			Definition: occurrence.Definition,
+		})
+	}
+	return occurrences
+}
+
+// customVariableAt returns the custom variable occurrence under the cursor, if any.
+// The $ itself counts as part of the variable.
+func customVariableAt(contents string, position protocol.Position) (customVariableOccurrence, bool) {
+	for _, occurrence := range customVariableOccurrences(contents) {
+		if occurrence.Range.Start.Line != position.Line {
+			continue
+		}
+		if position.Character+1 >= occurrence.Range.Start.Character && position.Character <= occurrence.Range.End.Character {
+			return occurrence, true
+		}
+	}
+	return customVariableOccurrence{}, false
+}
+
+type customVariableLocation struct {
+	URI protocol.URI
+	customVariableOccurrence
+}
+
+func (l customVariableLocation) Location() protocol.Location {
+	return protocol.Location{
+		URI:   l.URI,
+		Range: l.Range,
+	}
+}
+
+// findCustomVariable returns every occurrence of the custom variable named name, in root and all its related files (see relatedFiles)
+func findCustomVariable(root protocol.URI, name string) []customVariableLocation {
+	locations := make([]customVariableLocation, 0)
+	for _, uri := range relatedFiles(root) {
+		contents, err := file(uri)
+		if err != nil {
+			continue
+		}
+
+		for _, occurrence := range customVariableOccurrences(contents) {
+			if occurrence.Name == name {
+				locations = append(locations, customVariableLocation{URI: uri, customVariableOccurrence: occurrence})
+			}
+		}
+	}
+	return locations
+}
+
+// customVariableDefinitions returns the definitions of the custom variable named name, see findCustomVariable
+func customVariableDefinitions(root protocol.URI, name string) []customVariableLocation {
+	definitions := make([]customVariableLocation, 0)
+	for _, occurrence := range findCustomVariable(root, name) {
+		if occurrence.Definition {
+			definitions = append(definitions, occurrence)
+		}
+	}
+	return definitions
+}
+
+// customVariableTypeDefinition returns the definition that determines the type of the custom variable named name, along with that type.
+// Definitions of the form $a = $b are followed until a concrete value is found.
+func customVariableTypeDefinition(root protocol.URI, name string) (definition customVariableLocation, kind parser.ValueKind, found bool) {
+	visited := make(map[string]bool)
+	for !visited[name] {
+		visited[name] = true
+		definitions := customVariableDefinitions(root, name)
+		if len(definitions) == 0 {
+			return definition, kind, found
+		}
+
+		// Hyprland uses the last definition
+		definition = definitions[len(definitions)-1]
+		found = true
+		value := customVariableValue(definition)
+		if aliased := customVariableReferencePattern.FindStringSubmatch(value); aliased != nil && aliased[0] == value {
+			name = aliased[1]
+			continue
+		}
+
+		document, err := parser.Parse("$" + name + " = " + value)
+		if err != nil || len(document.Variables) == 0 {
+			return definition, parser.Custom, found
+		}
+		// A single color is also a valid gradient, but it's most likely meant as a color
+		if _, err := parser.ParseColor(value); err == nil {
+			return definition, parser.Color, found
+		}
+		return definition, document.Variables[0].Value.Kind, found
+	}
+	return definition, parser.Custom, found
+}
+
+// customVariableValue returns the raw value given to the variable at its definition
+func customVariableValue(definition customVariableLocation) string {
+	contents, err := file(definition.URI)
+	if err != nil {
+		return ""
+	}
+
+	lines := strings.Split(contents, "\n")
+	if int(definition.Range.Start.Line) >= len(lines) {
+		return ""
+	}
+
+	_, value, _ := strings.Cut(config.StripComment(lines[definition.Range.Start.Line]), "=")
+	return strings.TrimSpace(value)
+}
@@ -0,0 +1,106 @@
+package hyprls
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ewen-lbh/hyprls/config"
+	"go.lsp.dev/protocol"
+	"go.uber.org/zap"
+)
+
+// clientCanWatchFiles is true if the client supports registering file watchers dynamically
+var clientCanWatchFiles bool
+
+// watchedPatterns are the glob patterns the client was asked to watch, see watchIncludeGraph
+var watchedPatterns = make(map[string]bool)
+
+// watchIncludeGraph asks the client to notify us when the files related to uri change on disk, since its diagnostics depend on them.
+// Only the patterns that are not watched yet are registered, see unwatchedPatterns.
+func (h Handler) watchIncludeGraph(ctx context.Context, uri protocol.URI) {
+	if !clientCanWatchFiles {
+		return
+	}
+
+	patterns := unwatchedPatterns(uri)
+	if len(patterns) == 0 {
+		return
+	}
+
+	watchers := make([]protocol.FileSystemWatcher, 0, len(patterns))
+	for _, pattern := range patterns {
+		watchers = append(watchers, protocol.FileSystemWatcher{GlobPattern: pattern})
+	}
+	// Registrations can't be extended, each batch of patterns gets its own
+	id := fmt.Sprintf("watch-include-graph-%d", len(watchedPatterns))
+
+	// The client's response can only be read once we're done handling the current message
+	go func() {
+		err := h.Client.RegisterCapability(ctx, &protocol.RegistrationParams{
+			Registrations: []protocol.Registration{
+				{
+					ID:     id,
+					Method: protocol.MethodWorkspaceDidChangeWatchedFiles,
+					RegisterOptions: protocol.DidChangeWatchedFilesRegistrationOptions{
+						Watchers: watchers,
+					},
+				},
+			},
+		})
+		if err != nil {
+			logger.Debug("while registering file watchers", zap.Error(err))
+		}
+	}()
+}
+
+// unwatchedPatterns returns the paths of the files related to uri (see relatedFiles), along with the glob patterns they source,
+// so that files created later that match them are noticed too. Patterns already in watchedPatterns are left out, and the others are added to it.
+func unwatchedPatterns(uri protocol.URI) []string {
+	patterns := make([]string, 0)
+	add := func(pattern string) {
+		if !watchedPatterns[pattern] {
+			watchedPatterns[pattern] = true
+			patterns = append(patterns, pattern)
+		}
+	}
+
+	for _, related := range relatedFiles(uri) {
+		add(related.Filename())
+		contents, err := file(related)
+		if err != nil {
+			continue
+		}
+		for _, directive := range config.SourceDirectives(contents) {
+			if config.IsGlobPattern(directive.Path) {
+				add(config.ResolveSourcePath(related.Filename(), directive.Path))
+			}
+		}
+	}
+	return patterns
+}
+
+func (h Handler) DidChangeWatchedFiles(ctx context.Context, params *protocol.DidChangeWatchedFilesParams) error {
+	changed := make(map[protocol.URI]bool)
+	for _, change := range params.Changes {
+		changed[change.URI] = true
+		delete(diskFiles, change.URI)
+	}
+
+	for uri := range changed {
+		if _, opened := openedFiles[uri]; !opened {
+			h.publishDiagnostics(ctx, uri)
+		}
+	}
+
+	// Diagnostics of opened files depend on the files they source, which can now source other files
+	for uri := range openedFiles {
+		h.watchIncludeGraph(ctx, uri)
+		for _, included := range includedFiles(uri) {
+			if changed[included] {
+				h.publishDiagnostics(ctx, uri)
+				break
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package hyprls
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+
+	"go.lsp.dev/protocol"
+	"go.lsp.dev/uri"
+	"go.uber.org/zap"
+)
+
+func TestFileIsCachedUntilClosed(t *testing.T) {
+	resetState(zap.NewNop())
+	path := filepath.Join(t.TempDir(), "sourced.conf")
+	if err := os.WriteFile(path, []byte("$gap = 5\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	document := uri.File(path)
+
+	if contents, err := file(document); err != nil || contents != "$gap = 5\n" {
+		t.Fatalf("expected the file to be read from disk, got %q (%v)", contents, err)
+	}
+	if err := os.WriteFile(path, []byte("$gap = 10\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if contents, _ := file(document); contents != "$gap = 5\n" {
+		t.Errorf("expected the cached contents, got %q", contents)
+	}
+
+	Handler{}.DidClose(context.Background(), &protocol.DidCloseTextDocumentParams{TextDocument: protocol.TextDocumentIdentifier{URI: document}})
+	if contents, _ := file(document); contents != "$gap = 10\n" {
+		t.Errorf("expected the file to be read again once closed, got %q", contents)
+	}
+}
+
+func TestUnwatchedPatternsFollowTheIncludeGraph(t *testing.T) {
+	resetState(zap.NewNop())
+	directory := t.TempDir()
+	main := filepath.Join(directory, "hyprland.conf")
+	options.HyprlandConfigPath = main
+	files := map[string]string{
+		"hyprland.conf":       "source = ./binds.conf\nsource = ./conf.d/*.conf\n",
+		"binds.conf":          "bind = SUPER, Q, exec, kitty\n",
+		"conf.d/monitor.conf": "monitor = , preferred, auto, 1\n",
+	}
+	for name, contents := range files {
+		path := filepath.Join(directory, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected := []string{
+		main,
+		filepath.Join(directory, "binds.conf"),
+		filepath.Join(directory, "conf.d", "*.conf"),
+		filepath.Join(directory, "conf.d", "monitor.conf"),
+	}
+	patterns := unwatchedPatterns(uri.File(main))
+	slices.Sort(patterns)
+	slices.Sort(expected)
+	if !reflect.DeepEqual(patterns, expected) {
+		t.Errorf("expected %v to be watched, got %v", expected, patterns)
+	}
+
+	// Opening a sourced file needs no new watchers, it is part of the same graph
+	if patterns := unwatchedPatterns(uri.File(filepath.Join(directory, "binds.conf"))); len(patterns) != 0 {
+		t.Errorf("expected the patterns to be watched already, got %v", patterns)
+	}
+}
@@ -0,0 +1,110 @@
+package hyprls
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/ewen-lbh/hyprls/config"
+	parser_data "github.com/ewen-lbh/hyprls/parser/data"
+	"go.lsp.dev/protocol"
+)
+
+// windowRuleFilterCompletions proposes filters when the cursor is in a filter's name of a windowrulev2 = RULE, FILTERS... line.
+// ok is false if the cursor is somewhere else.
+func windowRuleFilterCompletions(line string, position protocol.Position) (items []protocol.CompletionItem, ok bool) {
+	if field, ok := argumentIndex(line, position); !ok || field == 0 {
+		return nil, false
+	}
+
+	beforeCursor := textBeforeCursor(line, position)
+
+	typed := typedWord(beforeCursor, func(r rune) bool { return !unicode.IsSpace(r) && r != ',' })
+	if strings.Contains(typed, ":") {
+		return nil, false
+	}
+
+	items = make([]protocol.CompletionItem, 0, len(parser_data.WindowRuleFilters))
+	for _, filter := range parser_data.WindowRuleFilters {
+		item := wordCompletion(filter.Name, protocol.CompletionItemKindProperty, typed, position)
+		item.TextEdit.NewText = filter.Name + ":"
+		item.Documentation = protocol.MarkupContent{
+			Kind:  protocol.Markdown,
+			Value: filter.Description,
+		}
+		items = append(items, item)
+	}
+	return items, true
+}
+
+// windowRuleCompletions proposes rules when the cursor is in the rule's name of a windowrule or windowrulev2 line.
+// ok is false if the cursor is somewhere else.
+func windowRuleCompletions(line string, position protocol.Position) (items []protocol.CompletionItem, ok bool) {
+	rules := make([]parser_data.RuleDefinition, 0, len(parser_data.WindowRules))
+	for _, rule := range parser_data.WindowRules {
+		rules = append(rules, rule.RuleDefinition)
+	}
+	return ruleCompletions(rules, line, position)
+}
+
+// ruleCompletions proposes the given rules when the cursor is in the rule's name, the first argument of line.
+// ok is false if the cursor is somewhere else.
+func ruleCompletions(rules []parser_data.RuleDefinition, line string, position protocol.Position) (items []protocol.CompletionItem, ok bool) {
+	beforeCursor := textBeforeCursor(line, position)
+	_, value, _ := strings.Cut(beforeCursor, "=")
+	if field, ok := argumentIndex(line, position); !ok || field != 0 || strings.Contains(strings.TrimSpace(value), " ") {
+		return nil, false
+	}
+
+	typed := typedWord(beforeCursor, func(r rune) bool { return !unicode.IsSpace(r) && r != '=' })
+	items = make([]protocol.CompletionItem, 0, len(rules))
+	for _, rule := range rules {
+		item := wordCompletion(rule.Name, protocol.CompletionItemKindFunction, typed, position)
+		item.Detail = rule.Arguments
+		item.Documentation = protocol.MarkupContent{
+			Kind:  protocol.Markdown,
+			Value: rule.Description,
+		}
+		items = append(items, item)
+	}
+	return items, true
+}
+
+// ruleHover documents the window or layer rule whose name is under the cursor, if any
+func ruleHover(line string, position protocol.Position) *protocol.Hover {
+	key := config.LineKey(line)
+	if key != "windowrule" && key != "windowrulev2" && key != "layerrule" {
+		return nil
+	}
+
+	arguments := config.LineArguments(line, int(position.Line))
+	if len(arguments) == 0 {
+		return nil
+	}
+	name, configRange := config.RuleName(arguments[0])
+	nameRange := lspRange(configRange)
+	if !within(nameRange, position) {
+		return nil
+	}
+
+	var rule parser_data.RuleDefinition
+	found := false
+	if key == "layerrule" {
+		rule, found = parser_data.FindLayerRule(name)
+	} else {
+		var windowRule parser_data.WindowRuleDefinition
+		windowRule, found = parser_data.FindWindowRule(name)
+		rule = windowRule.RuleDefinition
+	}
+	if !found {
+		return nil
+	}
+
+	return &protocol.Hover{
+		Contents: protocol.MarkupContent{
+			Kind:  protocol.Markdown,
+			Value: fmt.Sprintf("### %s\n%s", strings.TrimSpace(rule.Name+" "+rule.Arguments), rule.Description),
+		},
+		Range: &nameRange,
+	}
+}